@@ -27,3 +27,20 @@ func (r *AttachmentsResource) Confirm(ctx context.Context, attachmentID string)
 	}
 	return &attachment, nil
 }
+
+// Upload uploads content as a new attachment: it creates an upload URL,
+// uploads content to it and confirms the upload. params.Size is set to the
+// length of content.
+func (r *AttachmentsResource) Upload(ctx context.Context, params *CreateUploadParams, content []byte) (*Attachment, error) {
+	create := *params
+	create.Size = int64(len(content))
+
+	upload, err := r.CreateUpload(ctx, &create)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.client.upload(ctx, upload.UploadURL, create.ContentType, content); err != nil {
+		return nil, err
+	}
+	return r.Confirm(ctx, upload.AttachmentID)
+}
@@ -0,0 +1,68 @@
+// Command mailbreeze-smtp runs an SMTP submission server that relays messages
+// through the MailBreeze API. Clients authenticate with their API key as the
+// SMTP password.
+//
+// Usage:
+//
+//	mailbreeze-smtp -addr :587 -tls-cert cert.pem -tls-key key.pem
+//
+// Without a certificate, -insecure-auth is required for clients to
+// authenticate, and should only be used on loopback interfaces.
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/MailBreeze/mailbreeze-go"
+	"github.com/MailBreeze/mailbreeze-go/smtprelay"
+)
+
+func main() {
+	var (
+		addr         = flag.String("addr", ":2525", "address to listen on")
+		hostname     = flag.String("hostname", "", "host name to greet clients with (default: machine host name)")
+		certFile     = flag.String("tls-cert", "", "TLS certificate file for STARTTLS")
+		keyFile      = flag.String("tls-key", "", "TLS key file for STARTTLS")
+		baseURL      = flag.String("base-url", "", "MailBreeze API base URL")
+		insecureAuth = flag.Bool("insecure-auth", false, "allow AUTH without TLS")
+		maxBytes     = flag.Int64("max-message-bytes", smtprelay.DefaultMaxMessageBytes, "largest message accepted")
+	)
+	flag.Parse()
+
+	srv := &smtprelay.Server{
+		Addr:              *addr,
+		Hostname:          *hostname,
+		AllowInsecureAuth: *insecureAuth,
+		MaxMessageBytes:   *maxBytes,
+	}
+	if *baseURL != "" {
+		srv.ClientOptions = append(srv.ClientOptions, mailbreeze.WithBaseURL(*baseURL))
+	}
+	if *certFile != "" || *keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+		if err != nil {
+			log.Fatalf("mailbreeze-smtp: load certificate: %v", err)
+		}
+		srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	} else if !*insecureAuth {
+		log.Print("mailbreeze-smtp: no TLS certificate; clients cannot authenticate without -insecure-auth")
+	}
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		_ = srv.Close()
+	}()
+
+	log.Printf("mailbreeze-smtp: listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, smtprelay.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
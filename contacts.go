@@ -2,11 +2,20 @@ package mailbreeze
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
+// defaultPageSize is the page size used when paging through all results.
+const defaultPageSize = 100
+
+// errStopPaging stops paging helpers such as eachContact without reporting an error.
+var errStopPaging = errors.New("stop paging")
+
 // ContactsResource provides access to contact operations within a list.
 type ContactsResource struct {
 	client *HTTPClient
@@ -48,6 +57,40 @@ func (r *ContactsResource) List(ctx context.Context, params *ListContactsParams)
 	return &result, nil
 }
 
+// eachContact calls fn for every contact matching filter, fetching one page
+// at a time. The Page of filter is ignored. Returning errStopPaging from fn
+// stops without an error.
+func (r *ContactsResource) eachContact(ctx context.Context, filter *ListContactsParams, fn func(*Contact) error) error {
+	params := ListContactsParams{Limit: defaultPageSize}
+	if filter != nil {
+		params.Status = filter.Status
+		params.Search = filter.Search
+		if filter.Limit > 0 {
+			params.Limit = filter.Limit
+		}
+	}
+
+	for params.Page = 1; ; params.Page++ {
+		result, err := r.List(ctx, &params)
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Data {
+			if err := fn(&result.Data[i]); err != nil {
+				if err == errStopPaging {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if !result.Pagination.HasNext || len(result.Data) == 0 {
+			return nil
+		}
+	}
+}
+
 // Get retrieves a contact by ID.
 func (r *ContactsResource) Get(ctx context.Context, contactID string) (*Contact, error) {
 	var contact Contact
@@ -57,10 +100,124 @@ func (r *ContactsResource) Get(ctx context.Context, contactID string) (*Contact,
 	return &contact, nil
 }
 
-// Update updates a contact.
+// GetByEmail retrieves the contact with the given email address, ignoring case.
+// It returns a not found error if the list has no such contact.
+func (r *ContactsResource) GetByEmail(ctx context.Context, email string) (*Contact, error) {
+	email = strings.TrimSpace(email)
+
+	var found *Contact
+	err := r.eachContact(ctx, &ListContactsParams{Search: email}, func(contact *Contact) error {
+		if strings.EqualFold(contact.Email, email) {
+			found = contact
+			return errStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, newErrorFromStatus(http.StatusNotFound, "Contact not found", "", "", 0)
+	}
+	return found, nil
+}
+
+// UpsertResult is the result of an upsert.
+type UpsertResult struct {
+	Contact *Contact `json:"contact"`
+
+	// Created is true if the contact was created, false if an existing contact was updated.
+	Created bool `json:"created"`
+}
+
+// Upsert creates a contact or updates the existing contact with the same email.
+//
+// It uses the API's upsert endpoint. If the endpoint is not available, it
+// looks the contact up by email and updates or creates it, retrying as an
+// update if a concurrent writer created the contact first.
+func (r *ContactsResource) Upsert(ctx context.Context, params *CreateContactParams) (*UpsertResult, error) {
+	var result UpsertResult
+	err := r.client.Post(ctx, fmt.Sprintf("/api/v1/contact-lists/%s/contacts/upsert", r.listID), params, &result)
+	if err == nil {
+		return &result, nil
+	}
+
+	if !isEndpointUnavailable(err) {
+		return nil, err
+	}
+
+	return r.upsertFallback(ctx, params)
+}
+
+// isEndpointUnavailable reports whether err means the API does not provide
+// the requested endpoint, so the caller should fall back to simpler calls.
+func isEndpointUnavailable(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed)
+}
+
+// upsertFallback emulates an upsert with lookup, create and update calls.
+func (r *ContactsResource) upsertFallback(ctx context.Context, params *CreateContactParams) (*UpsertResult, error) {
+	existing, err := r.GetByEmail(ctx, params.Email)
+	if err != nil && !IsNotFoundError(err) {
+		return nil, err
+	}
+
+	if existing == nil {
+		contact, err := r.Create(ctx, params)
+		if err == nil {
+			return &UpsertResult{Contact: contact, Created: true}, nil
+		}
+		if !IsConflictError(err) {
+			return nil, err
+		}
+
+		// Created concurrently since the lookup, update it instead
+		existing, err = r.GetByEmail(ctx, params.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	contact, err := r.Update(ctx, existing.ID, updateParamsFromCreate(params))
+	if err != nil {
+		return nil, err
+	}
+	return &UpsertResult{Contact: contact, Created: false}, nil
+}
+
+// updateParamsFromCreate converts create parameters into update parameters.
+// Empty create fields are left unchanged.
+func updateParamsFromCreate(params *CreateContactParams) *UpdateContactParams {
+	update := &UpdateContactParams{
+		Email:            nonZero(params.Email),
+		FirstName:        nonZero(params.FirstName),
+		LastName:         nonZero(params.LastName),
+		PhoneNumber:      nonZero(params.PhoneNumber),
+		CustomFields:     params.CustomFields,
+		ConsentType:      nonZero(params.ConsentType),
+		ConsentSource:    nonZero(params.ConsentSource),
+		ConsentIpAddress: nonZero(params.ConsentIpAddress),
+	}
+	if params.ConsentTimestamp != nil {
+		update.ConsentTimestamp = Value(*params.ConsentTimestamp)
+	}
+	return update
+}
+
+// nonZero returns an unset Nullable for the zero value and v otherwise.
+func nonZero[T comparable](v T) Nullable[T] {
+	var zero T
+	if v == zero {
+		return Nullable[T]{}
+	}
+	return Value(v)
+}
+
+// Update partially updates a contact. Fields not set in params are unchanged.
 func (r *ContactsResource) Update(ctx context.Context, contactID string, params *UpdateContactParams) (*Contact, error) {
 	var contact Contact
-	if err := r.client.Put(ctx, fmt.Sprintf("/api/v1/contact-lists/%s/contacts/%s", r.listID, contactID), params, &contact); err != nil {
+	if err := r.client.Patch(ctx, fmt.Sprintf("/api/v1/contact-lists/%s/contacts/%s", r.listID, contactID), params, &contact); err != nil {
 		return nil, err
 	}
 	return &contact, nil
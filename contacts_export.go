@@ -0,0 +1,171 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ExportFormat is the output format of a contact export.
+type ExportFormat string
+
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// ErrExportFieldsChanged is returned by a CSV Export when a contact has a
+// custom field that no contact had while the header was built, such as a
+// field added by a concurrent update. Retrying the export includes it.
+var ErrExportFieldsChanged = errors.New("mailbreeze: custom fields changed during export")
+
+// exportColumns are the standard CSV columns, in order.
+var exportColumns = []string{
+	"id", "email", "firstName", "lastName", "phoneNumber", "status", "source",
+	"createdAt", "updatedAt", "subscribedAt", "unsubscribedAt",
+	"consentType", "consentSource", "consentTimestamp", "consentIpAddress",
+}
+
+// Export writes every contact in the list matching filter to w, one page at a
+// time. The Page of filter is ignored and its Limit sets the page size, which
+// defaults to 100.
+//
+// JSONL output has one contact object per line. CSV output has the standard
+// fields followed by one column per custom field, sorted by name. To build the
+// CSV header without buffering the list, the contacts are paged through twice;
+// if a custom field appears between the passes, Export stops with
+// ErrExportFieldsChanged rather than dropping its values.
+//
+// Export returns the number of contacts written.
+func (r *ContactsResource) Export(ctx context.Context, w io.Writer, format ExportFormat, filter *ListContactsParams) (int, error) {
+	switch format {
+	case ExportFormatJSONL:
+		return r.exportJSONL(ctx, w, filter)
+	case ExportFormatCSV:
+		return r.exportCSV(ctx, w, filter)
+	default:
+		return 0, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func (r *ContactsResource) exportJSONL(ctx context.Context, w io.Writer, filter *ListContactsParams) (int, error) {
+	encoder := json.NewEncoder(w)
+	count := 0
+	err := r.eachContact(ctx, filter, func(contact *Contact) error {
+		if err := encoder.Encode(contact); err != nil {
+			return fmt.Errorf("failed to write contact: %w", err)
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+func (r *ContactsResource) exportCSV(ctx context.Context, w io.Writer, filter *ListContactsParams) (int, error) {
+	// First pass collects custom field names for the header
+	names := make(map[string]struct{})
+	err := r.eachContact(ctx, filter, func(contact *Contact) error {
+		for name := range contact.CustomFields {
+			names[name] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	customFields := make([]string, 0, len(names))
+	for name := range names {
+		customFields = append(customFields, name)
+	}
+	sort.Strings(customFields)
+
+	csvWriter := csv.NewWriter(w)
+	header := append(append([]string{}, exportColumns...), customFields...)
+	if err := csvWriter.Write(header); err != nil {
+		return 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	count := 0
+	record := make([]string, len(header))
+	err = r.eachContact(ctx, filter, func(contact *Contact) error {
+		for name := range contact.CustomFields {
+			if _, ok := names[name]; !ok {
+				return fmt.Errorf("%w: contact %s has custom field %q", ErrExportFieldsChanged, contact.ID, name)
+			}
+		}
+		fillExportRecord(record, contact, customFields)
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write contact: %w", err)
+		}
+		count++
+		return nil
+	})
+	csvWriter.Flush()
+	if err != nil {
+		return count, err
+	}
+	if err := csvWriter.Error(); err != nil {
+		return count, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return count, nil
+}
+
+// fillExportRecord writes the CSV columns of contact into record.
+func fillExportRecord(record []string, contact *Contact, customFields []string) {
+	copy(record, []string{
+		contact.ID,
+		contact.Email,
+		contact.FirstName,
+		contact.LastName,
+		contact.PhoneNumber,
+		string(contact.Status),
+		contact.Source,
+		formatExportTime(&contact.CreatedAt),
+		formatExportTime(contact.UpdatedAt),
+		formatExportTime(contact.SubscribedAt),
+		formatExportTime(contact.UnsubscribedAt),
+		string(contact.ConsentType),
+		contact.ConsentSource,
+		formatExportTime(contact.ConsentTimestamp),
+		contact.ConsentIpAddress,
+	})
+
+	for i, name := range customFields {
+		record[len(exportColumns)+i] = formatExportValue(contact.CustomFields[name])
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatExportValue formats a custom field value as a CSV cell. Objects and
+// arrays are written as JSON.
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,246 @@
+package mailbreeze
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newExportServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		if r.URL.Query().Get("status") != "active" {
+			t.Errorf("expected status active, got %s", r.URL.Query().Get("status"))
+		}
+		if r.URL.Query().Get("limit") != "1" {
+			t.Errorf("expected limit 1, got %s", r.URL.Query().Get("limit"))
+		}
+
+		contact := map[string]interface{}{
+			"id":               "c1",
+			"email":            "jane@example.com",
+			"firstName":        "Jane",
+			"status":           "active",
+			"customFields":     map[string]interface{}{"plan": "pro", "age": 42, "vip": true},
+			"consentType":      "explicit",
+			"consentTimestamp": "2024-01-02T03:04:05Z",
+			"createdAt":        "2024-01-01T00:00:00Z",
+		}
+		hasNext := true
+		if r.URL.Query().Get("page") == "2" {
+			contact = map[string]interface{}{
+				"id":           "c2",
+				"email":        "john@example.com",
+				"status":       "active",
+				"customFields": map[string]interface{}{"company": "Acme, Inc.", "tags": []string{"a", "b"}},
+				"createdAt":    "2024-01-01T00:00:00Z",
+			}
+			hasNext = false
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"data":       []map[string]interface{}{contact},
+				"pagination": map[string]interface{}{"hasNext": hasNext},
+			},
+		})
+	}))
+}
+
+func TestContactsExportCSV(t *testing.T) {
+	requests := 0
+	server := newExportServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	var buf bytes.Buffer
+	count, err := client.Contacts("list_123").Export(context.Background(), &buf, ExportFormatCSV, &ListContactsParams{
+		Status: ContactStatusActive,
+		Limit:  1,
+		Page:   5,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 contacts, got %d", count)
+	}
+
+	if requests != 4 {
+		t.Errorf("expected 2 passes of 2 pages, got %d requests", requests)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	header := strings.Join(records[0], ",")
+	expected := "id,email,firstName,lastName,phoneNumber,status,source,createdAt,updatedAt,subscribedAt,unsubscribedAt,consentType,consentSource,consentTimestamp,consentIpAddress,age,company,plan,tags,vip"
+	if header != expected {
+		t.Errorf("unexpected header:\n%s", header)
+	}
+
+	jane := strings.Join(records[1], ",")
+	if jane != "c1,jane@example.com,Jane,,,active,,2024-01-01T00:00:00Z,,,,explicit,,2024-01-02T03:04:05Z,,42,,pro,,true" {
+		t.Errorf("unexpected row: %s", jane)
+	}
+
+	if records[2][16] != "Acme, Inc." || records[2][18] != `["a","b"]` {
+		t.Errorf("unexpected custom fields: %v", records[2])
+	}
+}
+
+func TestContactsExportJSONL(t *testing.T) {
+	requests := 0
+	server := newExportServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	var buf bytes.Buffer
+	count, err := client.Contacts("list_123").Export(context.Background(), &buf, ExportFormatJSONL, &ListContactsParams{
+		Status: ContactStatusActive,
+		Limit:  1,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 2 || requests != 2 {
+		t.Errorf("expected 2 contacts in 2 requests, got %d in %d", count, requests)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	var contact Contact
+	if err := json.Unmarshal([]byte(lines[0]), &contact); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contact.Email != "jane@example.com" || contact.ConsentType != ConsentTypeExplicit {
+		t.Errorf("unexpected contact: %+v", contact)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestContactsExportErrors(t *testing.T) {
+	requests := 0
+	server := newExportServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+	contacts := client.Contacts("list_123")
+	filter := &ListContactsParams{Status: ContactStatusActive, Limit: 1}
+
+	if _, err := contacts.Export(context.Background(), &bytes.Buffer{}, "xml", filter); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+
+	if _, err := contacts.Export(context.Background(), failingWriter{}, ExportFormatJSONL, filter); err == nil {
+		t.Error("expected write error for JSONL")
+	}
+
+	if _, err := contacts.Export(context.Background(), failingWriter{}, ExportFormatCSV, filter); err == nil {
+		t.Error("expected write error for CSV")
+	}
+
+	errServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "List not found"},
+		})
+	}))
+	defer errServer.Close()
+
+	errClient := NewClient("sk_test_123", WithBaseURL(errServer.URL))
+	if _, err := errClient.Contacts("list_123").Export(context.Background(), &bytes.Buffer{}, ExportFormatCSV, nil); !IsNotFoundError(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestContactsExportCSVFieldsChanged(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// The contact gains a custom field between the two passes
+		fields := map[string]interface{}{"plan": "pro"}
+		if requests > 1 {
+			fields["company"] = "Acme"
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"data": []map[string]interface{}{{
+					"id": "c1", "email": "jane@example.com", "status": "active",
+					"customFields": fields, "createdAt": "2024-01-01T00:00:00Z",
+				}},
+				"pagination": map[string]interface{}{"hasNext": false},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Contacts("list_123").Export(context.Background(), &bytes.Buffer{}, ExportFormatCSV, nil)
+	if !errors.Is(err, ErrExportFieldsChanged) || !strings.Contains(err.Error(), `"company"`) {
+		t.Errorf("expected ErrExportFieldsChanged for company, got %v", err)
+	}
+}
+
+func TestContactsExportImportRoundTrip(t *testing.T) {
+	requests := 0
+	server := newExportServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	var buf bytes.Buffer
+	if _, err := client.Contacts("list_123").Export(context.Background(), &buf, ExportFormatCSV, &ListContactsParams{Status: ContactStatusActive, Limit: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, _ := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	fields, err := mapImportHeader(records[0], &ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	params, err := parseImportRecord(records[1], fields, &ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if params.Email != "jane@example.com" || params.ConsentType != ConsentTypeExplicit || params.ConsentTimestamp == nil {
+		t.Errorf("unexpected params: %+v", params)
+	}
+
+	if _, ok := params.CustomFields["id"]; ok || params.CustomFields["plan"] != "pro" {
+		t.Errorf("expected only real custom fields, got %v", params.CustomFields)
+	}
+}
@@ -0,0 +1,492 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultImportBatchSize is the default number of rows Import sends per bulk
+// create request, and processes between checkpoints.
+const DefaultImportBatchSize = 500
+
+// DefaultImportConcurrency is the default number of contacts Import creates at
+// once when the bulk create endpoint is not available.
+const DefaultImportConcurrency = 4
+
+// ContactField identifies a contact field that a CSV column maps to.
+type ContactField string
+
+const (
+	ContactFieldEmail            ContactField = "email"
+	ContactFieldFirstName        ContactField = "firstName"
+	ContactFieldLastName         ContactField = "lastName"
+	ContactFieldPhoneNumber      ContactField = "phoneNumber"
+	ContactFieldSource           ContactField = "source"
+	ContactFieldConsentType      ContactField = "consentType"
+	ContactFieldConsentSource    ContactField = "consentSource"
+	ContactFieldConsentTimestamp ContactField = "consentTimestamp"
+	ContactFieldConsentIPAddress ContactField = "consentIpAddress"
+
+	// ContactFieldIgnore skips a column.
+	ContactFieldIgnore ContactField = "-"
+)
+
+// customFieldPrefix marks a ContactField that maps to a custom field.
+const customFieldPrefix = "customFields."
+
+// CustomField returns the ContactField for the custom field with the given name.
+func CustomField(name string) ContactField {
+	return ContactField(customFieldPrefix + name)
+}
+
+// contactFieldAliases maps normalized CSV headers to contact fields.
+var contactFieldAliases = map[string]ContactField{
+	"email":            ContactFieldEmail,
+	"emailaddress":     ContactFieldEmail,
+	"firstname":        ContactFieldFirstName,
+	"lastname":         ContactFieldLastName,
+	"phone":            ContactFieldPhoneNumber,
+	"phonenumber":      ContactFieldPhoneNumber,
+	"source":           ContactFieldSource,
+	"consenttype":      ContactFieldConsentType,
+	"consentsource":    ContactFieldConsentSource,
+	"consenttimestamp": ContactFieldConsentTimestamp,
+	"consentdate":      ContactFieldConsentTimestamp,
+	"consentip":        ContactFieldConsentIPAddress,
+	"consentipaddress": ContactFieldConsentIPAddress,
+
+	// Read-only columns written by Export
+	"id":             ContactFieldIgnore,
+	"status":         ContactFieldIgnore,
+	"createdat":      ContactFieldIgnore,
+	"updatedat":      ContactFieldIgnore,
+	"subscribedat":   ContactFieldIgnore,
+	"unsubscribedat": ContactFieldIgnore,
+}
+
+// importTimeLayouts are the accepted formats of consent timestamp columns.
+var importTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// ImportOptions configures a contact import.
+type ImportOptions struct {
+	// Mapping maps CSV headers to contact fields. Headers that are not mapped
+	// are matched against the standard field names, ignoring case, spaces,
+	// dashes and underscores. Remaining headers become custom fields.
+	Mapping map[string]ContactField
+
+	// IgnoreUnmapped skips headers that do not map to a standard field
+	// instead of importing them as custom fields.
+	IgnoreUnmapped bool
+
+	// Source is used for rows without a source column value.
+	Source string
+
+	// ConsentType is used for rows without a consent type column value.
+	ConsentType ConsentType
+
+	// BatchSize is the number of rows sent per bulk create request, and
+	// processed between checkpoints. Defaults to DefaultImportBatchSize.
+	BatchSize int
+
+	// Concurrency is the number of contacts created at once when the bulk
+	// create endpoint is not available. Defaults to DefaultImportConcurrency.
+	Concurrency int
+
+	// Checkpoint resumes an earlier import, skipping the rows it covers.
+	Checkpoint *ImportCheckpoint
+
+	// OnCheckpoint is called after each batch of rows has been processed.
+	OnCheckpoint func(ImportCheckpoint)
+}
+
+// ImportCheckpoint records how far an import progressed so it can be resumed.
+type ImportCheckpoint struct {
+	// Row is the last data row processed, counting from 1 and excluding the header.
+	Row int `json:"row"`
+}
+
+// ImportRowError describes a row that could not be imported.
+type ImportRowError struct {
+	// Row is the data row, counting from 1 and excluding the header.
+	Row   int
+	Email string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *ImportRowError) Error() string {
+	if e.Email != "" {
+		return fmt.Sprintf("row %d (%s): %v", e.Row, e.Email, e.Err)
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportResult summarizes a contact import.
+type ImportResult struct {
+	// Rows is the number of data rows read, including skipped rows.
+	Rows int
+
+	// Skipped is the number of rows skipped because of the resume checkpoint.
+	Skipped int
+
+	// Created is the number of contacts created.
+	Created int
+
+	// Failed is the number of rows that failed validation or creation.
+	Failed int
+
+	// Errors describes every failed row.
+	Errors []*ImportRowError
+
+	// Checkpoint is where to resume the import if it was interrupted.
+	Checkpoint ImportCheckpoint
+}
+
+// importRow is a parsed row waiting to be created.
+type importRow struct {
+	row    int
+	params *CreateContactParams
+}
+
+// bulkCreateResponse is the response of the bulk create endpoint. Failed
+// contacts are identified by their index in the request.
+type bulkCreateResponse struct {
+	Created []Contact `json:"created"`
+	Failed  []struct {
+		Index      int    `json:"index"`
+		StatusCode int    `json:"statusCode"`
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+	} `json:"failed"`
+}
+
+// Import creates contacts from CSV data. The first record must be a header.
+// Rows are streamed, validated and created in batches; invalid rows and
+// failed creations are reported per row and do not stop the import.
+//
+// Each batch is sent with the API's bulk create endpoint. If the endpoint is
+// not available, the contacts of each batch are created one at a time, with
+// up to Concurrency requests in flight.
+//
+// If ctx is cancelled or reading fails, the partial result is returned along
+// with the error. Its Checkpoint can be passed to a later Import to resume;
+// rows of the batch that was interrupted are processed again.
+func (r *ContactsResource) Import(ctx context.Context, reader io.Reader, opts *ImportOptions) (*ImportResult, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultImportConcurrency
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	fields, err := mapImportHeader(header, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	resumeAfter := 0
+	if opts.Checkpoint != nil {
+		resumeAfter = opts.Checkpoint.Row
+		result.Checkpoint = *opts.Checkpoint
+	}
+
+	var batch []importRow
+	bulk := true
+	flush := func(lastRow int) error {
+		if len(batch) > 0 {
+			if bulk {
+				bulk = r.importBulk(ctx, batch, result)
+			}
+			if !bulk {
+				r.importBatch(ctx, batch, concurrency, result)
+			}
+			batch = batch[:0]
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result.Checkpoint.Row = lastRow
+		if opts.OnCheckpoint != nil {
+			opts.OnCheckpoint(result.Checkpoint)
+		}
+		return nil
+	}
+
+	row := 0
+	pending := 0
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+		result.Rows++
+
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return result, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		if row <= resumeAfter {
+			result.Skipped++
+			continue
+		}
+
+		if err != nil {
+			result.addError(&ImportRowError{Row: row, Err: err})
+		} else if params, err := parseImportRecord(record, fields, opts); err != nil {
+			result.addError(&ImportRowError{Row: row, Email: params.Email, Err: err})
+		} else {
+			batch = append(batch, importRow{row: row, params: params})
+		}
+
+		pending++
+		if pending >= batchSize {
+			if err := flush(row); err != nil {
+				return result, err
+			}
+			pending = 0
+		}
+	}
+
+	if pending > 0 {
+		if err := flush(row); err != nil {
+			return result, err
+		}
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Row < result.Errors[j].Row
+	})
+	return result, nil
+}
+
+// importBulk creates the contacts of a batch with one bulk create request. It
+// returns false, without recording anything, if the endpoint is not available.
+func (r *ContactsResource) importBulk(ctx context.Context, batch []importRow, result *ImportResult) bool {
+	contacts := make([]*CreateContactParams, len(batch))
+	for i, item := range batch {
+		contacts[i] = item.params
+	}
+
+	var response bulkCreateResponse
+	path := fmt.Sprintf("/api/v1/contact-lists/%s/contacts/bulk", r.listID)
+	err := r.client.Post(ctx, path, map[string]interface{}{"contacts": contacts}, &response)
+	if isEndpointUnavailable(err) {
+		return false
+	}
+	if err != nil {
+		for _, item := range batch {
+			result.addError(&ImportRowError{Row: item.row, Email: item.params.Email, Err: err})
+		}
+		return true
+	}
+
+	result.Created += len(response.Created)
+	sort.Slice(response.Failed, func(i, j int) bool {
+		return response.Failed[i].Index < response.Failed[j].Index
+	})
+	for _, failed := range response.Failed {
+		if failed.Index < 0 || failed.Index >= len(batch) {
+			continue
+		}
+		item := batch[failed.Index]
+		result.addError(&ImportRowError{
+			Row:   item.row,
+			Email: item.params.Email,
+			Err:   newErrorFromStatus(failed.StatusCode, failed.Message, failed.Code, "", 0),
+		})
+	}
+	return true
+}
+
+// importBatch creates the contacts of a batch one at a time with bounded
+// concurrency.
+func (r *ContactsResource) importBatch(ctx context.Context, batch []importRow, concurrency int, result *ImportResult) {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	rowErrors := make([]*ImportRowError, len(batch))
+	for i, item := range batch {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item importRow) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if _, err := r.Create(ctx, item.params); err != nil {
+				rowErrors[i] = &ImportRowError{Row: item.row, Email: item.params.Email, Err: err}
+				return
+			}
+			mu.Lock()
+			result.Created++
+			mu.Unlock()
+		}(i, item)
+	}
+	wg.Wait()
+
+	// Report errors in row order
+	for _, rowErr := range rowErrors {
+		if rowErr != nil {
+			result.addError(rowErr)
+		}
+	}
+}
+
+func (res *ImportResult) addError(err *ImportRowError) {
+	res.Failed++
+	res.Errors = append(res.Errors, err)
+}
+
+// mapImportHeader resolves the contact field of every CSV column.
+func mapImportHeader(header []string, opts *ImportOptions) ([]ContactField, error) {
+	fields := make([]ContactField, len(header))
+	hasEmail := false
+
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+
+		field, ok := opts.Mapping[name]
+		if !ok {
+			field, ok = contactFieldAliases[normalizeHeader(name)]
+		}
+		if !ok {
+			field = CustomField(name)
+			if opts.IgnoreUnmapped || name == "" {
+				field = ContactFieldIgnore
+			}
+		}
+
+		fields[i] = field
+		if field == ContactFieldEmail {
+			hasEmail = true
+		}
+	}
+
+	if !hasEmail {
+		return nil, errors.New("CSV header has no email column")
+	}
+	return fields, nil
+}
+
+// normalizeHeader lowercases a header and strips spaces, dashes and underscores.
+func normalizeHeader(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// parseImportRecord converts a CSV record into contact parameters and
+// validates them. The returned params are never nil so the email can be
+// reported with validation errors.
+func parseImportRecord(record []string, fields []ContactField, opts *ImportOptions) (*CreateContactParams, error) {
+	params := &CreateContactParams{}
+
+	for i, value := range record {
+		if i >= len(fields) {
+			break
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		switch field := fields[i]; field {
+		case ContactFieldIgnore:
+		case ContactFieldEmail:
+			params.Email = value
+		case ContactFieldFirstName:
+			params.FirstName = value
+		case ContactFieldLastName:
+			params.LastName = value
+		case ContactFieldPhoneNumber:
+			params.PhoneNumber = value
+		case ContactFieldSource:
+			params.Source = value
+		case ContactFieldConsentType:
+			params.ConsentType = ConsentType(strings.ToLower(value))
+		case ContactFieldConsentSource:
+			params.ConsentSource = value
+		case ContactFieldConsentIPAddress:
+			params.ConsentIpAddress = value
+		case ContactFieldConsentTimestamp:
+			ts, err := parseImportTime(value)
+			if err != nil {
+				return params, err
+			}
+			params.ConsentTimestamp = &ts
+		default:
+			if params.CustomFields == nil {
+				params.CustomFields = make(map[string]interface{})
+			}
+			params.CustomFields[strings.TrimPrefix(string(field), customFieldPrefix)] = value
+		}
+	}
+
+	if params.Source == "" {
+		params.Source = opts.Source
+	}
+	if params.ConsentType == "" {
+		params.ConsentType = opts.ConsentType
+	}
+
+	if params.Email == "" {
+		return params, errors.New("missing email")
+	}
+	if _, _, ok := parseAddress(params.Email); !ok {
+		return params, errors.New("invalid email address")
+	}
+
+	switch params.ConsentType {
+	case "", ConsentTypeExplicit, ConsentTypeImplicit, ConsentTypeLegitimateInterest:
+	default:
+		return params, fmt.Errorf("invalid consent type %q", params.ConsentType)
+	}
+
+	return params, nil
+}
+
+// parseImportTime parses a consent timestamp in one of importTimeLayouts.
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if ts, err := time.Parse(layout, value); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid consent timestamp %q", value)
+}
@@ -0,0 +1,281 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestContactsImport(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]map[string]interface{}{}
+	bulkRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Without the bulk endpoint, contacts are created one at a time
+		if r.URL.Path == "/api/v1/contact-lists/list_123/contacts/bulk" {
+			mu.Lock()
+			bulkRequests++
+			mu.Unlock()
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Not found"},
+			})
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/contact-lists/list_123/contacts" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		email, _ := body["email"].(string)
+		if email == "dupe@example.com" {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "CONFLICT", "message": "Contact already exists"},
+			})
+			return
+		}
+
+		mu.Lock()
+		created[email] = body
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": "contact_" + email, "email": email},
+		})
+	}))
+	defer server.Close()
+
+	csvData := "\ufeffEmail Address,First Name,last_name,Phone,Plan,Consent Type,Consent Date,Notes\n" +
+		"jane@example.com,Jane,Doe,+2348000000000,pro,explicit,2024-01-02,\n" +
+		"not-an-email,Bad,Row,,,,,\n" +
+		"dupe@example.com,Dup,Licate,,,,,\n" +
+		"john@example.com,John,,,free,,,\"note, with comma\"\n" +
+		"bad-consent@example.com,,,,,sometimes,,\n" +
+		"bad-date@example.com,,,,,,yesterday,\n"
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	var checkpoints []int
+	result, err := client.Contacts("list_123").Import(context.Background(), strings.NewReader(csvData), &ImportOptions{
+		Mapping:      map[string]ContactField{"Notes": ContactFieldIgnore},
+		Source:       "csv-import",
+		BatchSize:    2,
+		Concurrency:  2,
+		OnCheckpoint: func(cp ImportCheckpoint) { checkpoints = append(checkpoints, cp.Row) },
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Rows != 6 || result.Created != 2 || result.Failed != 4 {
+		t.Errorf("expected 6 rows, 2 created, 4 failed, got %+v", result)
+	}
+
+	if bulkRequests != 1 {
+		t.Errorf("expected the bulk endpoint to be tried once, got %d requests", bulkRequests)
+	}
+
+	if len(checkpoints) != 3 || checkpoints[2] != 6 || result.Checkpoint.Row != 6 {
+		t.Errorf("expected checkpoints [2 4 6], got %v", checkpoints)
+	}
+
+	rows := []int{}
+	for _, rowErr := range result.Errors {
+		rows = append(rows, rowErr.Row)
+	}
+	if len(rows) != 4 || rows[0] != 2 || rows[1] != 3 || rows[2] != 5 || rows[3] != 6 {
+		t.Errorf("expected errors for rows [2 3 5 6], got %v", rows)
+	}
+
+	var apiErr *Error
+	if !errors.As(result.Errors[1], &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("expected conflict error for row 3, got %v", result.Errors[1])
+	}
+
+	jane := created["jane@example.com"]
+	if jane["firstName"] != "Jane" || jane["lastName"] != "Doe" || jane["phoneNumber"] != "+2348000000000" {
+		t.Errorf("unexpected standard fields: %v", jane)
+	}
+	if jane["consentType"] != "explicit" || jane["consentTimestamp"] != "2024-01-02T00:00:00Z" {
+		t.Errorf("unexpected consent fields: %v", jane)
+	}
+	if jane["source"] != "csv-import" {
+		t.Errorf("expected default source, got %v", jane["source"])
+	}
+	fields, _ := jane["customFields"].(map[string]interface{})
+	if fields["Plan"] != "pro" || len(fields) != 1 {
+		t.Errorf("expected Plan custom field only, got %v", fields)
+	}
+}
+
+func TestContactsImportBulk(t *testing.T) {
+	var batches [][]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/contact-lists/list_123/contacts/bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Contacts []CreateContactParams `json:"contacts"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		var emails []string
+		var created, failed []map[string]interface{}
+		for i, contact := range body.Contacts {
+			emails = append(emails, contact.Email)
+			if contact.Email == "dupe@example.com" {
+				failed = append(failed, map[string]interface{}{"index": i, "statusCode": 409, "code": "CONFLICT", "message": "Contact already exists"})
+				continue
+			}
+			created = append(created, map[string]interface{}{"id": "contact_" + contact.Email, "email": contact.Email})
+		}
+		batches = append(batches, emails)
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"created": created, "failed": failed},
+		})
+	}))
+	defer server.Close()
+
+	csvData := "email\na@example.com\nnot-an-email\ndupe@example.com\nb@example.com\nc@example.com\n"
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	result, err := client.Contacts("list_123").Import(context.Background(), strings.NewReader(csvData), &ImportOptions{BatchSize: 3})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 2 {
+		t.Errorf("expected 2 bulk requests of 2 contacts, got %v", batches)
+	}
+
+	if result.Created != 3 || result.Failed != 2 {
+		t.Errorf("expected 3 created and 2 failed, got %+v", result)
+	}
+
+	if len(result.Errors) != 2 || result.Errors[1].Row != 3 || !IsConflictError(result.Errors[1].Err) {
+		t.Errorf("expected a conflict for row 3, got %v", result.Errors)
+	}
+}
+
+func TestContactsImportResume(t *testing.T) {
+	var mu sync.Mutex
+	var emails []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/bulk") {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		emails = append(emails, body["email"].(string))
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": body})
+	}))
+	defer server.Close()
+
+	csvData := "email\na@example.com\nb@example.com\nc@example.com\n"
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Contacts("list_123").Import(context.Background(), strings.NewReader(csvData), &ImportOptions{
+		Checkpoint:     &ImportCheckpoint{Row: 2},
+		IgnoreUnmapped: true,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Skipped != 2 || result.Created != 1 {
+		t.Errorf("expected 2 skipped and 1 created, got %+v", result)
+	}
+
+	if len(emails) != 1 || emails[0] != "c@example.com" {
+		t.Errorf("expected only c@example.com to be created, got %v", emails)
+	}
+}
+
+func TestContactsImportCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": map[string]interface{}{"id": "c"}})
+	}))
+	defer server.Close()
+
+	csvData := "email\na@example.com\nb@example.com\nc@example.com\n"
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	result, err := client.Contacts("list_123").Import(ctx, strings.NewReader(csvData), &ImportOptions{BatchSize: 1})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if result == nil || result.Checkpoint.Row != 0 {
+		t.Errorf("expected checkpoint at row 0, got %+v", result)
+	}
+}
+
+func TestContactsImportHeaderErrors(t *testing.T) {
+	client := NewClient("sk_test_123", WithBaseURL("http://127.0.0.1:0"))
+	contacts := client.Contacts("list_123")
+
+	if _, err := contacts.Import(context.Background(), strings.NewReader(""), nil); err == nil {
+		t.Error("expected error for empty input")
+	}
+
+	if _, err := contacts.Import(context.Background(), strings.NewReader("name,phone\nJane,123\n"), nil); err == nil {
+		t.Error("expected error for missing email column")
+	}
+}
+
+func TestContactsImportMalformedRow(t *testing.T) {
+	client := NewClient("sk_test_123", WithBaseURL("http://127.0.0.1:0"))
+
+	result, err := client.Contacts("list_123").Import(context.Background(), strings.NewReader("email\n\"unterminated\n"), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Failed != 1 || result.Errors[0].Row != 1 {
+		t.Errorf("expected row 1 to fail, got %+v", result)
+	}
+
+	if !strings.Contains(result.Errors[0].Error(), "row 1") {
+		t.Errorf("expected row number in error, got %q", result.Errors[0].Error())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -133,8 +134,8 @@ func TestContactsGet(t *testing.T) {
 
 func TestContactsUpdate(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPut {
-			t.Errorf("expected PUT, got %s", r.Method)
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
 		}
 		if r.URL.Path != "/api/v1/contact-lists/list_123/contacts/contact_123" {
 			t.Errorf("expected /api/v1/contact-lists/list_123/contacts/contact_123, got %s", r.URL.Path)
@@ -156,7 +157,7 @@ func TestContactsUpdate(t *testing.T) {
 	client := NewClient("sk_test_123", WithBaseURL(server.URL))
 
 	contact, err := client.Contacts("list_123").Update(context.Background(), "contact_123", &UpdateContactParams{
-		Email: "updated@example.com",
+		Email: Value("updated@example.com"),
 	})
 
 	if err != nil {
@@ -227,3 +228,227 @@ func TestContactsSuppress(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestContactsGetByEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search") != "Jane@Example.com" {
+			t.Errorf("expected search 'Jane@Example.com', got '%s'", r.URL.Query().Get("search"))
+		}
+
+		// Search matches partially, the exact match is on the second page
+		contacts := []map[string]interface{}{
+			{"id": "contact_1", "email": "jane@example.com.au", "createdAt": "2024-01-01T00:00:00Z"},
+		}
+		hasNext := true
+		if r.URL.Query().Get("page") == "2" {
+			contacts = []map[string]interface{}{
+				{"id": "contact_2", "email": "jane@example.com", "createdAt": "2024-01-01T00:00:00Z"},
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"data":       contacts,
+				"pagination": map[string]interface{}{"hasNext": hasNext},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	contact, err := client.Contacts("list_123").GetByEmail(context.Background(), " Jane@Example.com ")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if contact.ID != "contact_2" {
+		t.Errorf("expected ID 'contact_2', got '%s'", contact.ID)
+	}
+}
+
+func TestContactsGetByEmailNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"data": []map[string]interface{}{}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Contacts("list_123").GetByEmail(context.Background(), "missing@example.com")
+
+	if !IsNotFoundError(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestContactsUpsertEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/contact-lists/list_123/contacts/upsert" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"contact": map[string]interface{}{"id": "contact_123", "email": "user@example.com", "createdAt": "2024-01-01T00:00:00Z"},
+				"created": true,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Contacts("list_123").Upsert(context.Background(), &CreateContactParams{Email: "user@example.com"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Created || result.Contact.ID != "contact_123" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+// upsertFallbackServer simulates an API without the upsert endpoint.
+func upsertFallbackServer(t *testing.T, existing bool, createStatus int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/upsert"):
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Route not found"},
+			})
+
+		case r.Method == http.MethodGet:
+			contacts := []map[string]interface{}{}
+			if existing {
+				contacts = append(contacts, map[string]interface{}{"id": "contact_123", "email": "user@example.com", "createdAt": "2024-01-01T00:00:00Z"})
+			}
+			// A concurrent writer creates the contact after the first lookup
+			existing = true
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"data": contacts},
+			})
+
+		case r.Method == http.MethodPost:
+			if createStatus != http.StatusCreated {
+				w.WriteHeader(createStatus)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"success": false,
+					"error":   map[string]interface{}{"code": codeFromStatus(createStatus), "message": "Create failed"},
+				})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"id": "contact_new", "email": "user@example.com", "createdAt": "2024-01-01T00:00:00Z"},
+			})
+
+		case r.Method == http.MethodPatch:
+			var body UpdateContactParams
+			json.NewDecoder(r.Body).Decode(&body)
+			if firstName, _ := body.FirstName.Get(); firstName != "Jane" {
+				t.Errorf("expected firstName 'Jane', got '%s'", firstName)
+			}
+			if body.LastName.IsSet() {
+				t.Error("expected lastName to be left unset")
+			}
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"id": "contact_123", "email": "user@example.com", "firstName": "Jane", "createdAt": "2024-01-01T00:00:00Z"},
+			})
+		}
+	}))
+}
+
+func TestContactsUpsertFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     bool
+		createStatus int
+		created      bool
+		contactID    string
+		requests     int
+	}{
+		{"creates missing contact", false, http.StatusCreated, true, "contact_new", 3},
+		{"updates existing contact", true, http.StatusCreated, false, "contact_123", 3},
+		{"updates contact created concurrently", false, http.StatusConflict, false, "contact_123", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := upsertFallbackServer(t, tt.existing, tt.createStatus, &requests)
+			defer server.Close()
+
+			client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+			result, err := client.Contacts("list_123").Upsert(context.Background(), &CreateContactParams{
+				Email:     "user@example.com",
+				FirstName: "Jane",
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Created != tt.created || result.Contact.ID != tt.contactID {
+				t.Errorf("unexpected result: created=%v id=%s", result.Created, result.Contact.ID)
+			}
+
+			if len(requests) != tt.requests {
+				t.Errorf("expected %d requests, got %v", tt.requests, requests)
+			}
+		})
+	}
+}
+
+func TestContactsUpsertErrors(t *testing.T) {
+	var requests []string
+	server := upsertFallbackServer(t, false, http.StatusBadRequest, &requests)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Contacts("list_123").Upsert(context.Background(), &CreateContactParams{Email: "user@example.com"})
+
+	if !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   map[string]interface{}{"code": "AUTHENTICATION_ERROR", "message": "Invalid API key"},
+		})
+	}))
+	defer authServer.Close()
+
+	authClient := NewClient("sk_test_123", WithBaseURL(authServer.URL))
+
+	_, err = authClient.Contacts("list_123").Upsert(context.Background(), &CreateContactParams{Email: "user@example.com"})
+
+	if !IsAuthenticationError(err) {
+		t.Fatalf("expected authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package mailbreeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultTransferBatchSize is the number of contacts sent per bulk move or copy request.
+const DefaultTransferBatchSize = 500
+
+// ContactTransfer is a contact copied or moved to the destination list.
+type ContactTransfer struct {
+	// ContactID is the ID of the contact in the source list.
+	ContactID string `json:"contactId"`
+
+	// NewContactID is the ID of the contact in the destination list.
+	NewContactID string `json:"newContactId"`
+}
+
+// ContactConflict is a contact that was not transferred because its email
+// already exists in the destination list.
+type ContactConflict struct {
+	ContactID         string `json:"contactId"`
+	Email             string `json:"email"`
+	ExistingContactID string `json:"existingContactId"`
+}
+
+// ContactTransferError describes a contact that could not be transferred.
+type ContactTransferError struct {
+	ContactID string
+	Err       error
+}
+
+// Error implements the error interface.
+func (e *ContactTransferError) Error() string {
+	return fmt.Sprintf("contact %s: %v", e.ContactID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ContactTransferError) Unwrap() error {
+	return e.Err
+}
+
+// ContactTransferResult summarizes a move or copy between lists.
+type ContactTransferResult struct {
+	Transferred []ContactTransfer
+	Conflicts   []ContactConflict
+	Errors      []*ContactTransferError
+}
+
+// bulkTransferResponse is the response of the bulk move and copy endpoints.
+type bulkTransferResponse struct {
+	Transferred []ContactTransfer `json:"transferred"`
+	Conflicts   []ContactConflict `json:"conflicts"`
+	Failed      []struct {
+		ContactID string `json:"contactId"`
+		Message   string `json:"message"`
+	} `json:"failed"`
+}
+
+// CopyTo copies contacts to another list, preserving their consent fields,
+// custom fields, source, subscription date and status. Contacts whose email
+// already exists in the destination list are reported as conflicts and left
+// unchanged.
+//
+// It uses the API's bulk copy endpoint. If the endpoint is not available, each
+// contact is fetched and created in the destination list; contacts that are
+// not active are suppressed there with the matching reason.
+func (r *ContactsResource) CopyTo(ctx context.Context, toListID string, contactIDs []string) (*ContactTransferResult, error) {
+	return r.transfer(ctx, toListID, contactIDs, false)
+}
+
+// MoveTo moves contacts to another list. It copies them like CopyTo and then
+// deletes them from this list. Conflicting and failed contacts are not deleted.
+func (r *ContactsResource) MoveTo(ctx context.Context, toListID string, contactIDs []string) (*ContactTransferResult, error) {
+	return r.transfer(ctx, toListID, contactIDs, true)
+}
+
+func (r *ContactsResource) transfer(ctx context.Context, toListID string, contactIDs []string, move bool) (*ContactTransferResult, error) {
+	if toListID == "" {
+		return nil, fmt.Errorf("mailbreeze: destination list ID is required")
+	}
+	if toListID == r.listID {
+		return nil, fmt.Errorf("mailbreeze: source and destination lists are the same")
+	}
+
+	operation := "copy"
+	if move {
+		operation = "move"
+	}
+	path := fmt.Sprintf("/api/v1/contact-lists/%s/contacts/%s", r.listID, operation)
+
+	result := &ContactTransferResult{}
+	for i, chunk := range chunkStrings(contactIDs, DefaultTransferBatchSize) {
+		body := map[string]interface{}{"targetListId": toListID, "contactIds": chunk}
+
+		var response bulkTransferResponse
+		err := r.client.Post(ctx, path, body, &response)
+		if isEndpointUnavailable(err) {
+			// Earlier chunks are already transferred; only fall back for the rest
+			remaining, err := r.transferFallback(ctx, toListID, contactIDs[i*DefaultTransferBatchSize:], move)
+			result.Transferred = append(result.Transferred, remaining.Transferred...)
+			result.Conflicts = append(result.Conflicts, remaining.Conflicts...)
+			result.Errors = append(result.Errors, remaining.Errors...)
+			return result, err
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result.Transferred = append(result.Transferred, response.Transferred...)
+		result.Conflicts = append(result.Conflicts, response.Conflicts...)
+		for _, failed := range response.Failed {
+			result.Errors = append(result.Errors, &ContactTransferError{
+				ContactID: failed.ContactID,
+				Err:       errors.New(failed.Message),
+			})
+		}
+	}
+	return result, nil
+}
+
+// transferFallback emulates a bulk move or copy with get, create, suppress
+// and delete calls for each contact.
+func (r *ContactsResource) transferFallback(ctx context.Context, toListID string, contactIDs []string, move bool) (*ContactTransferResult, error) {
+	dest := &ContactsResource{client: r.client, listID: toListID}
+	result := &ContactTransferResult{}
+
+	for _, contactID := range contactIDs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		fail := func(err error) {
+			result.Errors = append(result.Errors, &ContactTransferError{ContactID: contactID, Err: err})
+		}
+
+		contact, err := r.Get(ctx, contactID)
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		created, err := dest.Create(ctx, createParamsFromContact(contact))
+		if IsConflictError(err) {
+			conflict := ContactConflict{ContactID: contactID, Email: contact.Email}
+			if existing, err := dest.GetByEmail(ctx, contact.Email); err == nil {
+				conflict.ExistingContactID = existing.ID
+			}
+			result.Conflicts = append(result.Conflicts, conflict)
+			continue
+		}
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		if reason, ok := suppressReasonForStatus(contact.Status); ok {
+			if err := dest.Suppress(ctx, created.ID, reason); err != nil {
+				fail(fmt.Errorf("copied as %s but failed to suppress: %w", created.ID, err))
+				continue
+			}
+		}
+
+		if move {
+			if err := r.Delete(ctx, contactID); err != nil {
+				fail(fmt.Errorf("copied as %s but failed to delete: %w", created.ID, err))
+				continue
+			}
+		}
+
+		result.Transferred = append(result.Transferred, ContactTransfer{ContactID: contactID, NewContactID: created.ID})
+	}
+	return result, nil
+}
+
+// createParamsFromContact converts a contact into parameters that recreate it.
+func createParamsFromContact(contact *Contact) *CreateContactParams {
+	return &CreateContactParams{
+		Email:            contact.Email,
+		FirstName:        contact.FirstName,
+		LastName:         contact.LastName,
+		PhoneNumber:      contact.PhoneNumber,
+		CustomFields:     contact.CustomFields,
+		Source:           contact.Source,
+		SubscribedAt:     contact.SubscribedAt,
+		ConsentType:      contact.ConsentType,
+		ConsentSource:    contact.ConsentSource,
+		ConsentTimestamp: contact.ConsentTimestamp,
+		ConsentIpAddress: contact.ConsentIpAddress,
+	}
+}
+
+// suppressReasonForStatus returns the suppression reason that gives a new
+// contact the given status, or false for active contacts.
+func suppressReasonForStatus(status ContactStatus) (SuppressReason, bool) {
+	switch status {
+	case ContactStatusUnsubscribed:
+		return SuppressReasonUnsubscribed, true
+	case ContactStatusBounced:
+		return SuppressReasonBounced, true
+	case ContactStatusComplained:
+		return SuppressReasonComplained, true
+	case ContactStatusSuppressed:
+		return SuppressReasonManual, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,268 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestContactsCopyToBulk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/contact-lists/list_a/contacts/copy" {
+			t.Errorf("expected /api/v1/contact-lists/list_a/contacts/copy, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			TargetListID string   `json:"targetListId"`
+			ContactIDs   []string `json:"contactIds"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.TargetListID != "list_b" || len(body.ContactIDs) != 3 {
+			t.Errorf("unexpected body %+v", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"transferred": []map[string]interface{}{{"contactId": "c1", "newContactId": "n1"}},
+				"conflicts":   []map[string]interface{}{{"contactId": "c2", "email": "b@example.com", "existingContactId": "x2"}},
+				"failed":      []map[string]interface{}{{"contactId": "c3", "message": "Contact not found"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Contacts("list_a").CopyTo(context.Background(), "list_b", []string{"c1", "c2", "c3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Transferred) != 1 || result.Transferred[0].NewContactID != "n1" {
+		t.Errorf("unexpected transferred %+v", result.Transferred)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].ExistingContactID != "x2" {
+		t.Errorf("unexpected conflicts %+v", result.Conflicts)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Error() != "contact c3: Contact not found" {
+		t.Errorf("unexpected errors %v", result.Errors)
+	}
+}
+
+func TestContactsMoveToFallback(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	var created []CreateContactParams
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		respond := func(status int, data interface{}) {
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": status < 300, "data": data})
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/contact-lists/list_a/contacts/move":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Not found"},
+			})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/contact-lists/list_a/contacts/c1":
+			respond(http.StatusOK, map[string]interface{}{
+				"id":               "c1",
+				"email":            "a@example.com",
+				"status":           "unsubscribed",
+				"source":           "api",
+				"customFields":     map[string]interface{}{"plan": "pro"},
+				"subscribedAt":     "2023-05-01T00:00:00Z",
+				"consentType":      "explicit",
+				"consentTimestamp": "2023-05-01T00:00:00Z",
+				"consentIpAddress": "192.0.2.1",
+				"createdAt":        "2023-05-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/contact-lists/list_a/contacts/c2":
+			respond(http.StatusOK, map[string]interface{}{
+				"id": "c2", "email": "b@example.com", "status": "active", "createdAt": "2023-05-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/contact-lists/list_a/contacts/c3":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Contact not found"},
+			})
+
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/contact-lists/list_b/contacts":
+			var params CreateContactParams
+			json.NewDecoder(r.Body).Decode(&params)
+			created = append(created, params)
+			if params.Email == "b@example.com" {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"success": false,
+					"error":   map[string]interface{}{"code": "CONFLICT", "message": "Contact already exists"},
+				})
+				return
+			}
+			respond(http.StatusCreated, map[string]interface{}{"id": "n1", "email": params.Email, "createdAt": "2024-01-01T00:00:00Z"})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/contact-lists/list_b/contacts":
+			respond(http.StatusOK, map[string]interface{}{
+				"data":       []map[string]interface{}{{"id": "x2", "email": "b@example.com", "createdAt": "2024-01-01T00:00:00Z"}},
+				"pagination": map[string]interface{}{"hasNext": false},
+			})
+
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/contact-lists/list_b/contacts/n1/suppress":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["reason"] != string(SuppressReasonUnsubscribed) {
+				t.Errorf("expected reason unsubscribed, got %s", body["reason"])
+			}
+			respond(http.StatusOK, nil)
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/contact-lists/list_a/contacts/c1":
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Contacts("list_a").MoveTo(context.Background(), "list_b", []string{"c1", "c2", "c3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Transferred) != 1 || result.Transferred[0] != (ContactTransfer{ContactID: "c1", NewContactID: "n1"}) {
+		t.Errorf("unexpected transferred %+v", result.Transferred)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != (ContactConflict{ContactID: "c2", Email: "b@example.com", ExistingContactID: "x2"}) {
+		t.Errorf("unexpected conflicts %+v", result.Conflicts)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].ContactID != "c3" || !IsNotFoundError(result.Errors[0].Err) {
+		t.Errorf("unexpected errors %v", result.Errors)
+	}
+
+	params := created[0]
+	if params.Source != "api" || params.SubscribedAt == nil || params.ConsentType != ConsentTypeExplicit ||
+		params.ConsentTimestamp == nil || params.ConsentIpAddress != "192.0.2.1" || params.CustomFields["plan"] != "pro" {
+		t.Errorf("expected contact fields to be preserved, got %+v", params)
+	}
+
+	for _, call := range calls {
+		if strings.HasPrefix(call, "DELETE ") && call != "DELETE /api/v1/contact-lists/list_a/contacts/c1" {
+			t.Errorf("expected only c1 to be deleted, got %s", call)
+		}
+	}
+}
+
+func TestContactsMoveToFallbackAfterBulkChunk(t *testing.T) {
+	ids := make([]string, DefaultTransferBatchSize+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("c%d", i)
+	}
+	last := ids[len(ids)-1]
+
+	var mu sync.Mutex
+	var gets []string
+	bulkCalls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		respond := func(status int, data interface{}) {
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": status < 300, "data": data})
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/contact-lists/list_a/contacts/move":
+			// The first chunk is moved in bulk, then the endpoint disappears
+			bulkCalls++
+			if bulkCalls > 1 {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"success": false,
+					"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Not found"},
+				})
+				return
+			}
+			var body struct {
+				ContactIDs []string `json:"contactIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			transferred := make([]map[string]interface{}, len(body.ContactIDs))
+			for i, id := range body.ContactIDs {
+				transferred[i] = map[string]interface{}{"contactId": id, "newContactId": "n_" + id}
+			}
+			respond(http.StatusOK, map[string]interface{}{"transferred": transferred})
+
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/contact-lists/list_a/contacts/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v1/contact-lists/list_a/contacts/")
+			gets = append(gets, id)
+			respond(http.StatusOK, map[string]interface{}{
+				"id": id, "email": id + "@example.com", "status": "active", "createdAt": "2023-05-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/contact-lists/list_b/contacts":
+			respond(http.StatusCreated, map[string]interface{}{"id": "n_" + last, "createdAt": "2024-01-01T00:00:00Z"})
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/contact-lists/list_a/contacts/"+last:
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Contacts("list_a").MoveTo(context.Background(), "list_b", ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(gets) != 1 || gets[0] != last {
+		t.Errorf("expected only %s to be transferred by the fallback, got %v", last, gets)
+	}
+	if len(result.Transferred) != len(ids) || len(result.Errors) != 0 {
+		t.Errorf("expected %d transferred and no errors, got %d and %v", len(ids), len(result.Transferred), result.Errors)
+	}
+	if result.Transferred[len(ids)-1] != (ContactTransfer{ContactID: last, NewContactID: "n_" + last}) {
+		t.Errorf("unexpected last transfer %+v", result.Transferred[len(ids)-1])
+	}
+}
+
+func TestContactsTransferValidation(t *testing.T) {
+	client := NewClient("sk_test_123")
+
+	if _, err := client.Contacts("list_a").CopyTo(context.Background(), "", []string{"c1"}); err == nil {
+		t.Error("expected error for missing destination")
+	}
+	if _, err := client.Contacts("list_a").MoveTo(context.Background(), "list_a", []string{"c1"}); err == nil {
+		t.Error("expected error for same list")
+	}
+}
@@ -0,0 +1,178 @@
+package mailbreeze
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CustomFieldType is the value type of a custom field.
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText    CustomFieldType = "text"
+	CustomFieldTypeNumber  CustomFieldType = "number"
+	CustomFieldTypeDate    CustomFieldType = "date"
+	CustomFieldTypeBoolean CustomFieldType = "boolean"
+	CustomFieldTypeSelect  CustomFieldType = "select"
+)
+
+// CustomFieldDefinition is the schema of a contact custom field.
+type CustomFieldDefinition struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Label     string          `json:"label,omitempty"`
+	Type      CustomFieldType `json:"type"`
+	Required  bool            `json:"required"`
+	Options   []string        `json:"options,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// CreateCustomFieldParams are the parameters for defining a custom field.
+type CreateCustomFieldParams struct {
+	Name     string          `json:"name"`
+	Label    string          `json:"label,omitempty"`
+	Type     CustomFieldType `json:"type"`
+	Required bool            `json:"required,omitempty"`
+
+	// Options are the allowed values of a select field.
+	Options []string `json:"options,omitempty"`
+}
+
+// CustomFieldsResource provides access to custom field definitions.
+type CustomFieldsResource struct {
+	client *HTTPClient
+}
+
+// Create defines a new custom field.
+func (r *CustomFieldsResource) Create(ctx context.Context, params *CreateCustomFieldParams) (*CustomFieldDefinition, error) {
+	var field CustomFieldDefinition
+	if err := r.client.Post(ctx, "/api/v1/custom-fields", params, &field); err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// List returns every custom field definition.
+func (r *CustomFieldsResource) List(ctx context.Context) (CustomFieldSchema, error) {
+	var fields []CustomFieldDefinition
+	if err := r.client.Get(ctx, "/api/v1/custom-fields", nil, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// Delete deletes a custom field definition.
+func (r *CustomFieldsResource) Delete(ctx context.Context, fieldID string) error {
+	return r.client.Delete(ctx, fmt.Sprintf("/api/v1/custom-fields/%s", fieldID))
+}
+
+// CustomFieldSchema is the set of custom field definitions used to validate
+// custom fields before sending them to the API.
+type CustomFieldSchema []CustomFieldDefinition
+
+// Validate checks the custom fields of a new contact: every field must be
+// defined, have a value of the defined type, and required fields must be
+// present. Invalid fields are returned as FieldErrors.
+func (s CustomFieldSchema) Validate(fields map[string]interface{}) error {
+	return s.validate(fields, false)
+}
+
+// ValidateUpdate checks the custom fields of a contact update like Validate,
+// except that required fields may be missing. Nil values, which remove a
+// custom field, are rejected for required fields.
+func (s CustomFieldSchema) ValidateUpdate(fields map[string]interface{}) error {
+	return s.validate(fields, true)
+}
+
+func (s CustomFieldSchema) validate(fields map[string]interface{}, partial bool) error {
+	defs := make(map[string]*CustomFieldDefinition, len(s))
+	for i := range s {
+		defs[s[i].Name] = &s[i]
+	}
+
+	var errs FieldErrors
+	for _, name := range sortedFieldNames(fields) {
+		def, ok := defs[name]
+		if !ok {
+			errs = append(errs, FieldError{Field: name, Message: "unknown custom field"})
+			continue
+		}
+		value := fields[name]
+		if value == nil {
+			if def.Required {
+				errs = append(errs, FieldError{Field: name, Message: "required custom field cannot be null"})
+			}
+			continue
+		}
+		if msg := checkCustomFieldType(def, value); msg != "" {
+			errs = append(errs, FieldError{Field: name, Message: msg})
+		}
+	}
+
+	if !partial {
+		for _, def := range s {
+			if _, ok := fields[def.Name]; def.Required && !ok {
+				errs = append(errs, FieldError{Field: def.Name, Message: "required custom field is missing"})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return errs
+	}
+	return nil
+}
+
+// checkCustomFieldType returns why value does not match the type of def, or
+// an empty string if it does.
+func checkCustomFieldType(def *CustomFieldDefinition, value interface{}) string {
+	switch def.Type {
+	case CustomFieldTypeText:
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("expected text, got %s", jsonTypeName(value))
+		}
+	case CustomFieldTypeNumber:
+		if !isNumeric(value) {
+			return fmt.Sprintf("expected number, got %s", jsonTypeName(value))
+		}
+	case CustomFieldTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected boolean, got %s", jsonTypeName(value))
+		}
+	case CustomFieldTypeDate:
+		switch value.(type) {
+		case time.Time, *time.Time:
+		case string:
+			if _, err := coerceTime(value); err != nil {
+				return err.Error()
+			}
+		default:
+			return fmt.Sprintf("expected date, got %s", jsonTypeName(value))
+		}
+	case CustomFieldTypeSelect:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected one of %v, got %s", def.Options, jsonTypeName(value))
+		}
+		for _, option := range def.Options {
+			if v == option {
+				return ""
+			}
+		}
+		return fmt.Sprintf("expected one of %v, got %q", def.Options, v)
+	}
+	return ""
+}
+
+// sortedFieldNames returns the keys of fields in sorted order.
+func sortedFieldNames(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
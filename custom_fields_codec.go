@@ -0,0 +1,298 @@
+package mailbreeze
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// customFieldTag is the struct tag that maps struct fields to custom fields.
+const customFieldTag = "mailbreeze"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// taggedField is a struct field mapped to a custom field.
+type taggedField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+}
+
+// taggedFields returns the fields of t with a mailbreeze tag. A tag of "-"
+// skips the field, and the "omitempty" option leaves zero values out when
+// encoding.
+func taggedFields(t reflect.Type) []taggedField {
+	var fields []taggedField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup(customFieldTag)
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, taggedField{
+			index:     field.Index,
+			name:      name,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+	return fields
+}
+
+// DecodeCustomFields decodes the custom fields of contact into a struct of
+// type T using the mailbreeze struct tags of its fields:
+//
+//	type Profile struct {
+//		Company  string    `mailbreeze:"company"`
+//		Seats    int       `mailbreeze:"seats"`
+//		Trial    bool      `mailbreeze:"trial"`
+//		SignedUp time.Time `mailbreeze:"signed_up"`
+//	}
+//
+//	profile, err := mailbreeze.DecodeCustomFields[Profile](contact)
+//
+// Values are coerced to the field type: numbers and numeric strings to
+// integers and floats, "true"/"false" strings and 0/1 to booleans, RFC 3339
+// or date strings and Unix timestamps to time.Time, and numbers and booleans
+// to strings. Missing and null custom fields leave the zero value. Fields
+// that cannot be coerced are reported together as FieldErrors.
+func DecodeCustomFields[T any](contact *Contact) (T, error) {
+	var v T
+	if contact == nil {
+		return v, fmt.Errorf("mailbreeze: cannot decode custom fields of a nil contact")
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return v, fmt.Errorf("mailbreeze: cannot decode custom fields into %s", rv.Type())
+	}
+
+	var errs FieldErrors
+	for _, field := range taggedFields(rv.Type()) {
+		value, ok := contact.CustomFields[field.name]
+		if !ok || value == nil {
+			continue
+		}
+		if err := coerceCustomField(value, rv.FieldByIndex(field.index)); err != nil {
+			errs = append(errs, FieldError{Field: field.name, Message: err.Error()})
+		}
+	}
+	if len(errs) > 0 {
+		return v, errs
+	}
+	return v, nil
+}
+
+// EncodeCustomFields encodes a struct, or a pointer to one, into custom fields
+// using the mailbreeze struct tags of its fields. Times are encoded as RFC 3339
+// strings and nil pointers as null.
+func EncodeCustomFields(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("mailbreeze: cannot encode nil %s as custom fields", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mailbreeze: cannot encode %T as custom fields", v)
+	}
+
+	fields := make(map[string]interface{})
+	for _, field := range taggedFields(rv.Type()) {
+		value := rv.FieldByIndex(field.index)
+		if field.omitEmpty && value.IsZero() {
+			continue
+		}
+		fields[field.name] = encodeCustomField(value)
+	}
+	return fields, nil
+}
+
+// encodeCustomField returns the JSON value of a custom field.
+func encodeCustomField(value reflect.Value) interface{} {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(time.RFC3339)
+	}
+	return value.Interface()
+}
+
+// coerceCustomField stores a decoded JSON value in target, converting it to
+// the type of target.
+func coerceCustomField(value interface{}, target reflect.Value) error {
+	if target.Kind() == reflect.Pointer {
+		elem := reflect.New(target.Type().Elem())
+		if err := coerceCustomField(value, elem.Elem()); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+
+	if target.Type() == timeType {
+		ts, err := coerceTime(value)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(ts))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			target.SetString(v)
+		case float64:
+			target.SetString(strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			target.SetString(strconv.FormatBool(v))
+		default:
+			return typeMismatch(value, target.Type())
+		}
+
+	case reflect.Bool:
+		b, err := coerceBool(value)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := coerceNumber(value)
+		if err != nil {
+			return err
+		}
+		n := int64(f)
+		if float64(n) != f || target.OverflowInt(n) {
+			return fmt.Errorf("%v does not fit in %s", value, target.Type())
+		}
+		target.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := coerceNumber(value)
+		if err != nil {
+			return err
+		}
+		n := uint64(f)
+		if f < 0 || float64(n) != f || target.OverflowUint(n) {
+			return fmt.Errorf("%v does not fit in %s", value, target.Type())
+		}
+		target.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := coerceNumber(value)
+		if err != nil {
+			return err
+		}
+		if target.OverflowFloat(f) {
+			return fmt.Errorf("%v does not fit in %s", value, target.Type())
+		}
+		target.SetFloat(f)
+
+	case reflect.Interface:
+		if !reflect.TypeOf(value).AssignableTo(target.Type()) {
+			return typeMismatch(value, target.Type())
+		}
+		target.Set(reflect.ValueOf(value))
+
+	default:
+		// Slices, maps and structs go through a JSON round trip
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, target.Addr().Interface()); err != nil {
+			return typeMismatch(value, target.Type())
+		}
+	}
+	return nil
+}
+
+func coerceNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v)
+		}
+		return f, nil
+	default:
+		return 0, typeMismatch(value, reflect.TypeOf(float64(0)))
+	}
+}
+
+func coerceBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("invalid boolean %q", v)
+		}
+		return b, nil
+	case float64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+		return false, fmt.Errorf("invalid boolean %v", v)
+	default:
+		return false, typeMismatch(value, reflect.TypeOf(false))
+	}
+}
+
+// coerceTime accepts the formats of importTimeLayouts and Unix timestamps in seconds.
+func coerceTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case string:
+		for _, layout := range importTimeLayouts {
+			if ts, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return ts, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time %q", v)
+	case float64:
+		sec := int64(v)
+		return time.Unix(sec, int64((v-float64(sec))*float64(time.Second))).UTC(), nil
+	default:
+		return time.Time{}, typeMismatch(value, timeType)
+	}
+}
+
+func typeMismatch(value interface{}, t reflect.Type) error {
+	return fmt.Errorf("cannot use %s as %s", jsonTypeName(value), t)
+}
+
+// jsonTypeName returns the JSON type of a decoded JSON value.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,170 @@
+package mailbreeze
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testProfile struct {
+	Company  string      `mailbreeze:"company"`
+	Seats    int         `mailbreeze:"seats"`
+	Score    float64     `mailbreeze:"score"`
+	Trial    bool        `mailbreeze:"trial"`
+	SignedUp time.Time   `mailbreeze:"signed_up"`
+	Renewal  *time.Time  `mailbreeze:"renewal,omitempty"`
+	Tags     []string    `mailbreeze:"tags,omitempty"`
+	Extra    interface{} `mailbreeze:"extra,omitempty"`
+	Ignored  string      `mailbreeze:"-"`
+	Untagged string
+}
+
+func decodeContact(t *testing.T, fields string) *Contact {
+	t.Helper()
+	contact := &Contact{}
+	if err := json.Unmarshal([]byte(`{"customFields":`+fields+`}`), contact); err != nil {
+		t.Fatalf("failed to decode contact: %v", err)
+	}
+	return contact
+}
+
+func TestDecodeCustomFields(t *testing.T) {
+	contact := decodeContact(t, `{
+		"company": "Acme",
+		"seats": 12,
+		"score": "4.5",
+		"trial": "true",
+		"signed_up": "2024-03-01",
+		"renewal": 1735689600,
+		"tags": ["a", "b"],
+		"extra": {"k": "v"},
+		"Ignored": "x",
+		"Untagged": "y"
+	}`)
+
+	profile, err := DecodeCustomFields[testProfile](contact)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if profile.Company != "Acme" || profile.Seats != 12 || profile.Score != 4.5 || !profile.Trial {
+		t.Errorf("unexpected scalar fields: %+v", profile)
+	}
+	if !profile.SignedUp.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected signed_up %v", profile.SignedUp)
+	}
+	if profile.Renewal == nil || !profile.Renewal.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected renewal %v", profile.Renewal)
+	}
+	if !reflect.DeepEqual(profile.Tags, []string{"a", "b"}) {
+		t.Errorf("unexpected tags %v", profile.Tags)
+	}
+	if !reflect.DeepEqual(profile.Extra, map[string]interface{}{"k": "v"}) {
+		t.Errorf("unexpected extra %v", profile.Extra)
+	}
+	if profile.Ignored != "" || profile.Untagged != "" {
+		t.Errorf("expected untagged fields to be skipped, got %+v", profile)
+	}
+}
+
+func TestDecodeCustomFieldsCoercion(t *testing.T) {
+	type target struct {
+		Text  string `mailbreeze:"text"`
+		Flag  bool   `mailbreeze:"flag"`
+		Count *int   `mailbreeze:"count"`
+	}
+
+	profile, err := DecodeCustomFields[target](decodeContact(t, `{"text": 42, "flag": 1, "count": "7"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Text != "42" || !profile.Flag || profile.Count == nil || *profile.Count != 7 {
+		t.Errorf("unexpected result %+v", profile)
+	}
+
+	profile, err = DecodeCustomFields[target](decodeContact(t, `{"count": null}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Count != nil {
+		t.Errorf("expected nil count, got %v", *profile.Count)
+	}
+}
+
+func TestDecodeCustomFieldsErrors(t *testing.T) {
+	type target struct {
+		Seats  int       `mailbreeze:"seats"`
+		Small  int8      `mailbreeze:"small"`
+		Trial  bool      `mailbreeze:"trial"`
+		Joined time.Time `mailbreeze:"joined"`
+		Name   string    `mailbreeze:"name"`
+	}
+
+	_, err := DecodeCustomFields[target](decodeContact(t, `{
+		"seats": 1.5,
+		"small": 300,
+		"trial": "maybe",
+		"joined": "yesterday",
+		"name": ["x"]
+	}`))
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+	if len(fieldErrs) != 5 {
+		t.Errorf("expected 5 field errors, got %d: %v", len(fieldErrs), err)
+	}
+
+	if _, err := DecodeCustomFields[string](&Contact{}); err == nil {
+		t.Error("expected error decoding into non-struct")
+	}
+
+	if _, err := DecodeCustomFields[target](nil); err == nil {
+		t.Error("expected error decoding a nil contact")
+	}
+}
+
+func TestEncodeCustomFields(t *testing.T) {
+	signedUp := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	fields, err := EncodeCustomFields(&testProfile{
+		Company:  "Acme",
+		Seats:    12,
+		SignedUp: signedUp,
+		Ignored:  "x",
+		Untagged: "y",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"company":   "Acme",
+		"seats":     12,
+		"score":     float64(0),
+		"trial":     false,
+		"signed_up": "2024-03-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+
+	// Round trip through JSON and back
+	data, _ := json.Marshal(fields)
+	profile, err := DecodeCustomFields[testProfile](decodeContact(t, string(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Seats != 12 || !profile.SignedUp.Equal(signedUp) {
+		t.Errorf("unexpected round trip result %+v", profile)
+	}
+
+	if _, err := EncodeCustomFields("not a struct"); err == nil {
+		t.Error("expected error encoding non-struct")
+	}
+	if _, err := EncodeCustomFields((*testProfile)(nil)); err == nil {
+		t.Error("expected error encoding nil pointer")
+	}
+}
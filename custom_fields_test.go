@@ -0,0 +1,171 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCustomFieldsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/custom-fields" {
+			t.Errorf("expected /api/v1/custom-fields, got %s", r.URL.Path)
+		}
+
+		var body CreateCustomFieldParams
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Name != "plan" || body.Type != CustomFieldTypeSelect || len(body.Options) != 2 {
+			t.Errorf("unexpected body %+v", body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"id":        "field_123",
+				"name":      "plan",
+				"type":      "select",
+				"options":   []string{"free", "pro"},
+				"createdAt": "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	field, err := client.CustomFields.Create(context.Background(), &CreateCustomFieldParams{
+		Name:    "plan",
+		Type:    CustomFieldTypeSelect,
+		Options: []string{"free", "pro"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if field.ID != "field_123" {
+		t.Errorf("expected ID 'field_123', got '%s'", field.ID)
+	}
+}
+
+func TestCustomFieldsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": []map[string]interface{}{
+				{"id": "field_1", "name": "company", "type": "text", "required": true},
+				{"id": "field_2", "name": "seats", "type": "number"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	schema, err := client.CustomFields.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schema) != 2 || !schema[0].Required {
+		t.Fatalf("unexpected schema %+v", schema)
+	}
+
+	if err := schema.Validate(map[string]interface{}{"company": "Acme", "seats": 3}); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestCustomFieldsDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/custom-fields/field_123" {
+			t.Errorf("expected /api/v1/custom-fields/field_123, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	if err := client.CustomFields.Delete(context.Background(), "field_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCustomFieldSchemaValidate(t *testing.T) {
+	schema := CustomFieldSchema{
+		{Name: "company", Type: CustomFieldTypeText, Required: true},
+		{Name: "seats", Type: CustomFieldTypeNumber},
+		{Name: "trial", Type: CustomFieldTypeBoolean},
+		{Name: "renewal", Type: CustomFieldTypeDate},
+		{Name: "plan", Type: CustomFieldTypeSelect, Options: []string{"free", "pro"}},
+	}
+
+	valid := map[string]interface{}{
+		"company": "Acme",
+		"seats":   float64(12),
+		"trial":   true,
+		"renewal": time.Now(),
+		"plan":    "pro",
+	}
+	if err := schema.Validate(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := schema.Validate(map[string]interface{}{"company": "Acme", "renewal": "2024-01-01"}); err != nil {
+		t.Errorf("unexpected error for date string: %v", err)
+	}
+
+	err := schema.Validate(map[string]interface{}{
+		"seats":   "12",
+		"trial":   "yes",
+		"renewal": "soon",
+		"plan":    "enterprise",
+		"unknown": 1,
+	})
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+
+	fields := make([]string, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		fields[i] = fieldErr.Field
+	}
+	expected := []string{"company", "plan", "renewal", "seats", "trial", "unknown"}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected errors for %v, got %v", expected, fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("expected errors for %v, got %v", expected, fields)
+			break
+		}
+	}
+}
+
+func TestCustomFieldSchemaValidateUpdate(t *testing.T) {
+	schema := CustomFieldSchema{
+		{Name: "company", Type: CustomFieldTypeText, Required: true},
+		{Name: "seats", Type: CustomFieldTypeNumber},
+	}
+
+	if err := schema.ValidateUpdate(map[string]interface{}{"seats": nil}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := schema.ValidateUpdate(map[string]interface{}{"company": nil}); err == nil {
+		t.Error("expected error clearing required field")
+	}
+}
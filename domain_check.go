@@ -0,0 +1,147 @@
+package mailbreeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Resolver performs the DNS lookups used by DomainChecker.
+// *net.Resolver implements this interface.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// parkingProviders are name server and mail exchanger domains of domain
+// parking services.
+var parkingProviders = []string{
+	"above.com", "afternic.com", "bodis.com", "dan.com", "hugedomains.com",
+	"parkingcrew.net", "parklogic.com", "sedoparking.com", "undeveloped.com",
+	"ztomy.com",
+}
+
+// DomainCheckResult is the result of a local DNS deliverability check.
+type DomainCheckResult struct {
+	// Domain is the checked domain in ASCII form.
+	Domain string
+
+	// Exists is false when the domain has neither MX nor address records,
+	// which includes domains that do not exist.
+	Exists bool
+
+	// MXRecords are the mail exchanger hosts ordered by preference.
+	MXRecords []string
+
+	// NullMX is true when the domain publishes a null MX record (RFC 7505),
+	// explicitly declaring that it does not accept mail.
+	NullMX bool
+
+	// FallbackA is true when the domain has no MX records but has A or AAAA
+	// records, which are used as an implicit MX (RFC 5321 section 5.1).
+	FallbackA bool
+
+	// Parked is true when the domain appears to be parked.
+	Parked bool
+
+	// ParkedBy is the parking provider that matched, if any.
+	ParkedBy string
+
+	// AcceptsMail is true when DNS indicates the domain can receive mail.
+	AcceptsMail bool
+}
+
+// DomainChecker checks domain deliverability with DNS lookups, without
+// calling the MailBreeze API.
+type DomainChecker struct {
+	// Resolver performs DNS lookups. Defaults to net.DefaultResolver.
+	Resolver Resolver
+}
+
+// CheckDomain checks a domain using net.DefaultResolver.
+func CheckDomain(ctx context.Context, domain string) (*DomainCheckResult, error) {
+	return (&DomainChecker{}).Check(ctx, domain)
+}
+
+// Check reports the MX, null MX, A/AAAA fallback and parking status of domain.
+// A domain that does not exist is reported in the result, not as an error.
+// An error is returned for invalid domains and failed lookups.
+func (c *DomainChecker) Check(ctx context.Context, domain string) (*DomainCheckResult, error) {
+	resolver := c.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	ascii, err := domainToASCII(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if err != nil || !validDomain(ascii) {
+		return nil, fmt.Errorf("mailbreeze: invalid domain %q", domain)
+	}
+
+	result := &DomainCheckResult{Domain: ascii, Exists: true}
+
+	records, err := resolver.LookupMX(ctx, ascii)
+	if err != nil && !isDNSNotFound(err) {
+		return nil, fmt.Errorf("mailbreeze: MX lookup for %s failed: %w", ascii, err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Pref < records[j].Pref
+	})
+	for _, mx := range records {
+		result.MXRecords = append(result.MXRecords, strings.TrimSuffix(strings.ToLower(mx.Host), "."))
+	}
+
+	if len(records) == 1 && (records[0].Host == "." || records[0].Host == "") {
+		result.NullMX = true
+		result.MXRecords = nil
+	}
+
+	if len(records) == 0 {
+		addrs, err := resolver.LookupIPAddr(ctx, ascii)
+		switch {
+		case isDNSNotFound(err):
+			result.Exists = false
+			return result, nil
+		case err != nil:
+			return nil, fmt.Errorf("mailbreeze: address lookup for %s failed: %w", ascii, err)
+		}
+		result.FallbackA = len(addrs) > 0
+	}
+
+	nameServers, err := resolver.LookupNS(ctx, ascii)
+	if err != nil && !isDNSNotFound(err) {
+		return nil, fmt.Errorf("mailbreeze: NS lookup for %s failed: %w", ascii, err)
+	}
+
+	hosts := append([]string{}, result.MXRecords...)
+	for _, ns := range nameServers {
+		hosts = append(hosts, strings.TrimSuffix(strings.ToLower(ns.Host), "."))
+	}
+	result.ParkedBy = parkingProvider(hosts)
+	result.Parked = result.ParkedBy != ""
+
+	result.AcceptsMail = !result.NullMX && (len(result.MXRecords) > 0 || result.FallbackA)
+	return result, nil
+}
+
+// parkingProvider returns the parking provider any of hosts belongs to.
+func parkingProvider(hosts []string) string {
+	for _, host := range hosts {
+		for _, provider := range parkingProviders {
+			if host == provider || strings.HasSuffix(host, "."+provider) {
+				return provider
+			}
+		}
+	}
+	return ""
+}
+
+// isDNSNotFound reports whether err means the queried name or record does not exist.
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
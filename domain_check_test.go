@@ -0,0 +1,179 @@
+package mailbreeze
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	ips   map[string][]net.IPAddr
+	ns    map[string][]*net.NS
+	err   error
+	calls []string
+}
+
+func (f *fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	f.calls = append(f.calls, "MX "+name)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if records, ok := f.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (f *fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	f.calls = append(f.calls, "A "+host)
+	if addrs, ok := f.ips[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func (f *fakeResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	f.calls = append(f.calls, "NS "+name)
+	if records, ok := f.ns[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestDomainCheckerMX(t *testing.T) {
+	resolver := &fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com": {
+				{Host: "MX2.example.com.", Pref: 20},
+				{Host: "mx1.example.com.", Pref: 10},
+			},
+		},
+	}
+	checker := &DomainChecker{Resolver: resolver}
+
+	result, err := checker.Check(context.Background(), "Example.com")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.AcceptsMail || !result.Exists {
+		t.Errorf("expected domain to accept mail, got %+v", result)
+	}
+
+	if len(result.MXRecords) != 2 || result.MXRecords[0] != "mx1.example.com" || result.MXRecords[1] != "mx2.example.com" {
+		t.Errorf("expected MX records ordered by preference, got %v", result.MXRecords)
+	}
+
+	if result.FallbackA || result.NullMX || result.Parked {
+		t.Errorf("unexpected flags: %+v", result)
+	}
+
+	for _, call := range resolver.calls {
+		if call == "A example.com" {
+			t.Error("expected no address lookup when MX records exist")
+		}
+	}
+}
+
+func TestDomainCheckerNullMX(t *testing.T) {
+	checker := &DomainChecker{Resolver: &fakeResolver{
+		mx: map[string][]*net.MX{"example.org": {{Host: ".", Pref: 0}}},
+	}}
+
+	result, err := checker.Check(context.Background(), "example.org")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.NullMX || result.AcceptsMail {
+		t.Errorf("expected null MX domain not to accept mail, got %+v", result)
+	}
+
+	if len(result.MXRecords) != 0 {
+		t.Errorf("expected no MX records, got %v", result.MXRecords)
+	}
+}
+
+func TestDomainCheckerFallbackA(t *testing.T) {
+	checker := &DomainChecker{Resolver: &fakeResolver{
+		ips: map[string][]net.IPAddr{"example.net": {{IP: net.ParseIP("192.0.2.1")}}},
+	}}
+
+	result, err := checker.Check(context.Background(), "example.net.")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.FallbackA || !result.AcceptsMail {
+		t.Errorf("expected A record fallback, got %+v", result)
+	}
+}
+
+func TestDomainCheckerNotFound(t *testing.T) {
+	checker := &DomainChecker{Resolver: &fakeResolver{}}
+
+	result, err := checker.Check(context.Background(), "does-not-exist.example")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Exists || result.AcceptsMail {
+		t.Errorf("expected missing domain, got %+v", result)
+	}
+}
+
+func TestDomainCheckerParked(t *testing.T) {
+	checker := &DomainChecker{Resolver: &fakeResolver{
+		mx: map[string][]*net.MX{"parked.com": {{Host: "mail.parked.com.", Pref: 10}}},
+		ns: map[string][]*net.NS{"parked.com": {{Host: "NS1.SedoParking.com."}}},
+	}}
+
+	result, err := checker.Check(context.Background(), "parked.com")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Parked || result.ParkedBy != "sedoparking.com" {
+		t.Errorf("expected domain parked by sedoparking.com, got %+v", result)
+	}
+}
+
+func TestDomainCheckerIDN(t *testing.T) {
+	resolver := &fakeResolver{
+		mx: map[string][]*net.MX{"xn--bcher-kva.de": {{Host: "mx.xn--bcher-kva.de.", Pref: 10}}},
+	}
+	checker := &DomainChecker{Resolver: resolver}
+
+	result, err := checker.Check(context.Background(), "bücher.de")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Domain != "xn--bcher-kva.de" || !result.AcceptsMail {
+		t.Errorf("expected IDN domain to be converted, got %+v", result)
+	}
+}
+
+func TestDomainCheckerErrors(t *testing.T) {
+	checker := &DomainChecker{Resolver: &fakeResolver{err: errors.New("timeout")}}
+
+	if _, err := checker.Check(context.Background(), "example.com"); err == nil {
+		t.Error("expected lookup error")
+	}
+
+	if _, err := checker.Check(context.Background(), "not a domain"); err == nil {
+		t.Error("expected invalid domain error")
+	}
+
+	if _, err := CheckDomain(context.Background(), "localhost"); err == nil {
+		t.Error("expected invalid domain error")
+	}
+}
@@ -26,6 +26,9 @@ func TestErrorHelpersWithNonAPIError(t *testing.T) {
 	if IsRateLimitError(genericErr) {
 		t.Error("expected false for generic error")
 	}
+	if IsConflictError(genericErr) {
+		t.Error("expected false for generic error")
+	}
 	if IsServerError(genericErr) {
 		t.Error("expected false for generic error")
 	}
@@ -167,7 +170,7 @@ func TestAPICallErrors(t *testing.T) {
 		{
 			name: "lists update error",
 			testFunc: func(client *Client) error {
-				_, err := client.Lists.Update(context.Background(), "list_123", &UpdateListParams{Name: "new"})
+				_, err := client.Lists.Update(context.Background(), "list_123", &UpdateListParams{Name: Value("new")})
 				return err
 			},
 		},
@@ -195,7 +198,7 @@ func TestAPICallErrors(t *testing.T) {
 		{
 			name: "contacts update error",
 			testFunc: func(client *Client) error {
-				_, err := client.Contacts("list_123").Update(context.Background(), "contact_123", &UpdateContactParams{Email: "new@b.com"})
+				_, err := client.Contacts("list_123").Update(context.Background(), "contact_123", &UpdateContactParams{Email: Value("new@b.com")})
 				return err
 			},
 		},
@@ -9,11 +9,58 @@ import (
 
 // EmailsResource provides access to email operations.
 type EmailsResource struct {
-	client *HTTPClient
+	client          *HTTPClient
+	templateSchemas *templateSchemaCache
+	markdown        *MarkdownOptions
+	transformers    []BodyTransformer
 }
 
 // Send sends an email.
+//
+// When template validation is enabled with WithTemplateValidation, the
+// Variables of a template send are checked against the variables the
+// template declares, and FieldErrors are returned without sending.
+//
+// A Markdown body is converted with RenderMarkdown, and then transformers
+// added with WithBodyTransformer are applied. Both work on a copy of params;
+// params itself is not modified.
+//
+// With Personalizations, one message is sent per personalization and the
+// result lists the message ID of each recipient. If the API cannot send them
+// in one request, they are sent one at a time; on failure the result holds
+// the messages already sent.
 func (r *EmailsResource) Send(ctx context.Context, params *SendEmailParams, opts ...RequestOption) (*SendEmailResult, error) {
+	if params == nil {
+		return nil, fmt.Errorf("mailbreeze: params are required")
+	}
+	if len(params.Personalizations) > 0 {
+		if err := validatePersonalizations(params); err != nil {
+			return nil, err
+		}
+	}
+	if r.templateSchemas != nil {
+		if err := r.templateSchemas.validate(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Markdown != "" || len(r.transformers) > 0 {
+		transformed := *params
+		if err := RenderMarkdown(&transformed, r.markdown); err != nil {
+			return nil, err
+		}
+		for _, transform := range r.transformers {
+			if err := transform(&transformed); err != nil {
+				return nil, fmt.Errorf("mailbreeze: transform body: %w", err)
+			}
+		}
+		params = &transformed
+	}
+
+	if len(params.Personalizations) > 0 {
+		return r.sendPersonalized(ctx, params, opts...)
+	}
+
 	var result SendEmailResult
 	if err := r.client.Post(ctx, "/api/v1/emails", params, &result, opts...); err != nil {
 		return nil, err
@@ -44,6 +91,27 @@ func (r *EmailsResource) List(ctx context.Context, params *ListEmailsParams) (*E
 	return &result, nil
 }
 
+// eachEmail calls fn for every email, fetching one page at a time.
+func (r *EmailsResource) eachEmail(ctx context.Context, fn func(*Email) error) error {
+	params := ListEmailsParams{Limit: defaultPageSize}
+	for params.Page = 1; ; params.Page++ {
+		result, err := r.List(ctx, &params)
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Data {
+			if err := fn(&result.Data[i]); err != nil {
+				return err
+			}
+		}
+
+		if !result.Pagination.HasNext || len(result.Data) == 0 {
+			return nil
+		}
+	}
+}
+
 // Get retrieves an email by ID (or messageId).
 func (r *EmailsResource) Get(ctx context.Context, emailID string) (*Email, error) {
 	// API returns {"email": {...}} inside the data wrapper
@@ -0,0 +1,103 @@
+package mailbreeze
+
+import (
+	"context"
+	"fmt"
+)
+
+// validatePersonalizations checks the recipients of a personalized send.
+func validatePersonalizations(params *SendEmailParams) error {
+	var errs FieldErrors
+	recipients := []struct {
+		field string
+		list  []string
+	}{{"to", params.To}, {"cc", params.CC}, {"bcc", params.BCC}}
+	for _, r := range recipients {
+		if len(r.list) > 0 {
+			errs = append(errs, FieldError{Field: r.field, Message: "cannot be used together with personalizations"})
+		}
+	}
+	for i, p := range params.Personalizations {
+		if len(p.To) == 0 {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("personalizations[%d].to", i), Message: "is required"})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// sendPersonalized sends params with Personalizations through the batch
+// endpoint. If the endpoint is not available, each personalization is sent
+// with a separate call and the result holds the messages sent before the
+// first failure. An idempotency key is then suffixed with the index of the
+// personalization, so retrying the whole send is still idempotent.
+func (r *EmailsResource) sendPersonalized(ctx context.Context, params *SendEmailParams, opts ...RequestOption) (*SendEmailResult, error) {
+	var result SendEmailResult
+	err := r.client.Post(ctx, "/api/v1/emails/batch", params, &result, opts...)
+	if err == nil {
+		if result.MessageID == "" && len(result.Recipients) > 0 {
+			result.MessageID = result.Recipients[0].MessageID
+		}
+		return &result, nil
+	}
+	if !isEndpointUnavailable(err) {
+		return nil, err
+	}
+
+	var options requestOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for i, p := range params.Personalizations {
+		single := personalize(params, p)
+		singleOpts := opts
+		if options.IdempotencyKey != "" {
+			// Each message needs its own key, or the API would deduplicate
+			// them into the first one
+			key := fmt.Sprintf("%s-%d", options.IdempotencyKey, i)
+			singleOpts = append(opts[:len(opts):len(opts)], WithIdempotencyKey(key))
+		}
+		var sent SendEmailResult
+		if err := r.client.Post(ctx, "/api/v1/emails", single, &sent, singleOpts...); err != nil {
+			return &result, fmt.Errorf("mailbreeze: personalization %d: %w", i, err)
+		}
+		if result.MessageID == "" {
+			result.MessageID = sent.MessageID
+		}
+		for _, list := range [][]string{p.To, p.CC, p.BCC} {
+			for _, email := range list {
+				result.Recipients = append(result.Recipients, RecipientMessage{Email: email, MessageID: sent.MessageID})
+			}
+		}
+	}
+	return &result, nil
+}
+
+// personalize returns the single send of one personalization of params.
+func personalize(params *SendEmailParams, p Personalization) *SendEmailParams {
+	single := *params
+	single.Personalizations = nil
+	single.To, single.CC, single.BCC = p.To, p.CC, p.BCC
+	single.Variables = mergeMaps(params.Variables, p.Variables)
+	single.Headers = mergeMaps(params.Headers, p.Headers)
+	return &single
+}
+
+// mergeMaps returns base with the entries of override added, without
+// modifying either map.
+func mergeMaps[V any](base, override map[string]V) map[string]V {
+	if len(override) == 0 {
+		return base
+	}
+	merged := make(map[string]V, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
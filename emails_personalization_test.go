@@ -0,0 +1,240 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestEmailsSendPersonalizationsBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/emails/batch" {
+			t.Errorf("expected /api/v1/emails/batch, got %s", r.URL.Path)
+		}
+
+		var body SendEmailParams
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Personalizations) != 2 || body.Personalizations[1].Variables["name"] != "Bob" {
+			t.Errorf("unexpected body %+v", body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"recipients": []map[string]interface{}{
+					{"email": "alice@example.com", "messageId": "msg_1"},
+					{"email": "bob@example.com", "messageId": "msg_2"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Emails.Send(context.Background(), &SendEmailParams{
+		From:       "hello@example.com",
+		TemplateID: "tmpl_123",
+		Personalizations: []Personalization{
+			{To: []string{"alice@example.com"}, Variables: map[string]interface{}{"name": "Alice"}},
+			{To: []string{"bob@example.com"}, Variables: map[string]interface{}{"name": "Bob"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MessageID != "msg_1" || len(result.Recipients) != 2 || result.Recipients[1].MessageID != "msg_2" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestEmailsSendPersonalizationsFallback(t *testing.T) {
+	var mu sync.Mutex
+	var sent []SendEmailParams
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/api/v1/emails/batch" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Route not found"},
+			})
+			return
+		}
+
+		var body SendEmailParams
+		json.NewDecoder(r.Body).Decode(&body)
+		sent = append(sent, body)
+
+		if len(sent) == 3 {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "FORBIDDEN", "message": "Suppressed recipient"},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"messageId": fmt.Sprintf("msg_%d", len(sent))},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	params := &SendEmailParams{
+		From:      "hello@example.com",
+		Subject:   "Hi {{name}}",
+		HTML:      "<p>Hi {{name}}</p>",
+		Variables: map[string]interface{}{"name": "there", "company": "Acme"},
+		Headers:   map[string]string{"X-Campaign": "spring"},
+		Personalizations: []Personalization{
+			{To: []string{"alice@example.com"}, CC: []string{"manager@example.com"}, Variables: map[string]interface{}{"name": "Alice"}},
+			{To: []string{"bob@example.com"}, Headers: map[string]string{"X-Segment": "b"}},
+			{To: []string{"carol@example.com"}},
+		},
+	}
+	result, err := client.Emails.Send(context.Background(), params)
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected authorization error for third personalization, got %v", err)
+	}
+	if len(sent) != 3 {
+		t.Fatalf("expected 3 sends, got %d", len(sent))
+	}
+
+	first, second := sent[0], sent[1]
+	if len(first.To) != 1 || first.To[0] != "alice@example.com" || len(first.CC) != 1 || first.Personalizations != nil {
+		t.Errorf("unexpected first send %+v", first)
+	}
+	if first.Variables["name"] != "Alice" || first.Variables["company"] != "Acme" {
+		t.Errorf("expected merged variables, got %v", first.Variables)
+	}
+	if second.Variables["name"] != "there" || second.Headers["X-Segment"] != "b" || second.Headers["X-Campaign"] != "spring" {
+		t.Errorf("unexpected second send %+v", second)
+	}
+	if params.Variables["name"] != "there" {
+		t.Error("expected params not to be modified")
+	}
+
+	want := []RecipientMessage{
+		{Email: "alice@example.com", MessageID: "msg_1"},
+		{Email: "manager@example.com", MessageID: "msg_1"},
+		{Email: "bob@example.com", MessageID: "msg_2"},
+	}
+	if result == nil || result.MessageID != "msg_1" || fmt.Sprint(result.Recipients) != fmt.Sprint(want) {
+		t.Errorf("expected partial result %v, got %+v", want, result)
+	}
+}
+
+func TestEmailsSendPersonalizationsFallbackIdempotencyKeys(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/emails/batch" {
+			if key := r.Header.Get("X-Idempotency-Key"); key != "send-1" {
+				t.Errorf("expected batch request to use the caller's key, got %q", key)
+			}
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "METHOD_NOT_ALLOWED", "message": "Method not allowed"},
+			})
+			return
+		}
+
+		keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"messageId": fmt.Sprintf("msg_%d", len(keys))},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Emails.Send(context.Background(), &SendEmailParams{
+		From:    "hello@example.com",
+		Subject: "Hi",
+		HTML:    "<p>Hi</p>",
+		Personalizations: []Personalization{
+			{To: []string{"alice@example.com"}},
+			{To: []string{"bob@example.com"}},
+		},
+	}, WithIdempotencyKey("send-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != "send-1-0" || keys[1] != "send-1-1" {
+		t.Errorf("expected a distinct key per personalization, got %q", keys)
+	}
+}
+
+func TestEmailsSendPersonalizationsValidation(t *testing.T) {
+	client := NewClient("sk_test_123", WithBaseURL("http://127.0.0.1:0"))
+
+	_, err := client.Emails.Send(context.Background(), &SendEmailParams{
+		From:             "hello@example.com",
+		To:               []string{"user@example.com"},
+		Personalizations: []Personalization{{To: []string{"a@example.com"}}, {}},
+	})
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 2 {
+		t.Fatalf("expected 2 field errors, got %v", err)
+	}
+	if fieldErrs[0].Field != "to" || fieldErrs[1].Field != "personalizations[1].to" {
+		t.Errorf("unexpected field errors %v", fieldErrs)
+	}
+}
+
+func TestEmailsSendPersonalizationsTemplateValidation(t *testing.T) {
+	var templateGets, sends int32
+	server := newTemplateValidationServer(t, &templateGets, &sends)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithTemplateValidation(0))
+
+	_, err := client.Emails.Send(context.Background(), &SendEmailParams{
+		From:       "hello@example.com",
+		TemplateID: "tmpl_123",
+		Personalizations: []Personalization{
+			{To: []string{"alice@example.com"}, Variables: map[string]interface{}{"first_name": "Alice"}},
+			{To: []string{"bob@example.com"}},
+		},
+	})
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 1 || fieldErrs[0].Field != "personalizations[1].variables.first_name" {
+		t.Fatalf("expected missing first_name for second personalization, got %v", err)
+	}
+	if sends != 0 {
+		t.Error("expected invalid send not to reach the API")
+	}
+}
+
+func TestEmailsSendNilParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	if _, err := client.Emails.Send(context.Background(), nil); err == nil {
+		t.Fatal("expected error for nil params")
+	}
+}
@@ -0,0 +1,358 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// RawMessage is an RFC 5322 message parsed by ParseRawMessage.
+type RawMessage struct {
+	// Params holds the addresses, subject, extra headers and bodies of the
+	// message. AttachmentIDs is empty until the attachments are uploaded.
+	Params SendEmailParams
+
+	// Attachments are the attachments and inline parts of the message.
+	Attachments []RawAttachment
+}
+
+// RawAttachment is an attachment or inline part of a RawMessage.
+type RawAttachment struct {
+	Filename    string
+	ContentType string
+
+	// ContentID is the Content-ID of an inline part, without angle brackets.
+	ContentID string
+	Inline    bool
+	Content   []byte
+}
+
+// rawStructuralHeaders are handled by ParseRawMessage or set by the API, and
+// are not copied to SendEmailParams.Headers.
+var rawStructuralHeaders = map[string]bool{
+	"From": true, "To": true, "Cc": true, "Bcc": true, "Reply-To": true,
+	"Subject": true, "Date": true, "Message-Id": true, "Sender": true,
+	"Mime-Version": true, "Content-Type": true, "Content-Transfer-Encoding": true,
+	"Content-Disposition": true, "Content-Id": true, "Return-Path": true,
+	"Received": true, "Dkim-Signature": true,
+}
+
+// ParseRawMessage parses an RFC 5322 message, such as the contents of an .eml
+// file. The From, To, Cc, Bcc, Reply-To and Subject headers are mapped to
+// their SendEmailParams fields and other non-structural headers to Headers.
+// The first text/plain and text/html parts become the Text and HTML bodies;
+// other parts become Attachments.
+//
+// Bodies in UTF-8, US-ASCII and ISO-8859-1 are converted to UTF-8; other
+// charsets are passed through unchanged.
+func ParseRawMessage(r io.Reader) (*RawMessage, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("mailbreeze: parse message: %w", err)
+	}
+
+	raw := &RawMessage{}
+	p := &raw.Params
+
+	if p.From, err = singleAddress(msg.Header, "From"); err != nil {
+		return nil, err
+	}
+	if p.ReplyTo, err = joinedAddresses(msg.Header, "Reply-To"); err != nil {
+		return nil, err
+	}
+	if p.To, err = addressList(msg.Header, "To"); err != nil {
+		return nil, err
+	}
+	if p.CC, err = addressList(msg.Header, "Cc"); err != nil {
+		return nil, err
+	}
+	if p.BCC, err = addressList(msg.Header, "Bcc"); err != nil {
+		return nil, err
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charsetReader}
+	if subject := msg.Header.Get("Subject"); subject != "" {
+		if p.Subject, err = decoder.DecodeHeader(subject); err != nil {
+			p.Subject = subject
+		}
+	}
+
+	for key, values := range msg.Header {
+		key = textproto.CanonicalMIMEHeaderKey(key)
+		if rawStructuralHeaders[key] || len(values) == 0 {
+			continue
+		}
+		if p.Headers == nil {
+			p.Headers = make(map[string]string)
+		}
+		p.Headers[key] = strings.Join(values, ", ")
+	}
+
+	if err := raw.readPart(textproto.MIMEHeader(msg.Header), msg.Body); err != nil {
+		return nil, fmt.Errorf("mailbreeze: parse message: %w", err)
+	}
+	return raw, nil
+}
+
+// SendRaw parses an RFC 5322 message with ParseRawMessage and sends it with
+// SendRawMessage.
+func (r *EmailsResource) SendRaw(ctx context.Context, msg io.Reader, opts ...RequestOption) (*SendEmailResult, error) {
+	raw, err := ParseRawMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return r.SendRawMessage(ctx, raw, opts...)
+}
+
+// SendRawMessage uploads the attachments and inline parts of msg with
+// Attachments.Upload and sends it. Inline parts keep their Content-ID, so
+// cid: references in the HTML body still resolve.
+func (r *EmailsResource) SendRawMessage(ctx context.Context, msg *RawMessage, opts ...RequestOption) (*SendEmailResult, error) {
+	params := msg.Params
+	params.AttachmentIDs = append([]string(nil), params.AttachmentIDs...)
+
+	attachments := &AttachmentsResource{client: r.client}
+	for _, att := range msg.Attachments {
+		uploaded, err := attachments.Upload(ctx, &CreateUploadParams{
+			Filename:    att.Filename,
+			ContentType: att.ContentType,
+			Inline:      att.Inline,
+			ContentID:   att.ContentID,
+		}, att.Content)
+		if err != nil {
+			return nil, fmt.Errorf("mailbreeze: upload %s: %w", att.Filename, err)
+		}
+		params.AttachmentIDs = append(params.AttachmentIDs, uploaded.ID)
+	}
+
+	return r.Send(ctx, &params, opts...)
+}
+
+// SetEnvelope makes an SMTP envelope authoritative over the message headers:
+// To and Cc addresses that are not in rcpts are dropped, rcpts missing from
+// To and Cc become BCC, and from is used when the message has no From header.
+func (m *RawMessage) SetEnvelope(from string, rcpts []string) {
+	pending := make(map[string]bool, len(rcpts))
+	for _, rcpt := range rcpts {
+		pending[strings.ToLower(rcpt)] = true
+	}
+	keep := func(list []string) []string {
+		var kept []string
+		for _, addr := range list {
+			key := strings.ToLower(bareAddress(addr))
+			if pending[key] {
+				kept = append(kept, addr)
+				delete(pending, key)
+			}
+		}
+		return kept
+	}
+
+	p := &m.Params
+	p.To = keep(p.To)
+	p.CC = keep(p.CC)
+	p.BCC = nil
+	for _, rcpt := range rcpts {
+		if pending[strings.ToLower(rcpt)] {
+			p.BCC = append(p.BCC, rcpt)
+			delete(pending, strings.ToLower(rcpt))
+		}
+	}
+
+	if p.From == "" {
+		p.From = from
+	}
+}
+
+// readPart adds the body or attachments of a MIME part to m.
+func (m *RawMessage) readPart(header textproto.MIMEHeader, body io.Reader) error {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain; charset=us-ascii"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return fmt.Errorf("%s part without boundary", mediaType)
+		}
+		reader := multipart.NewReader(body, boundary)
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := m.readPart(part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if decoded, err := (&mime.WordDecoder{CharsetReader: charsetReader}).DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+
+	isBody := disposition != "attachment" && filename == ""
+	switch {
+	case isBody && mediaType == "text/plain" && m.Params.Text == "":
+		m.Params.Text = toUTF8(content, params["charset"])
+		return nil
+	case isBody && mediaType == "text/html" && m.Params.HTML == "":
+		m.Params.HTML = toUTF8(content, params["charset"])
+		return nil
+	}
+
+	contentID := strings.Trim(header.Get("Content-Id"), "<> ")
+	if filename == "" {
+		filename = "attachment-" + fmt.Sprint(len(m.Attachments)+1)
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			filename += exts[0]
+		}
+	}
+	m.Attachments = append(m.Attachments, RawAttachment{
+		Filename:    filename,
+		ContentType: mediaType,
+		ContentID:   contentID,
+		Inline:      disposition == "inline" || (disposition == "" && contentID != ""),
+		Content:     content,
+	})
+	return nil
+}
+
+// decodeTransfer decodes a part body with its Content-Transfer-Encoding.
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Filter{r: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// base64Filter drops the whitespace base64.NewDecoder does not skip, such as
+// spaces and tabs at the end of lines.
+type base64Filter struct {
+	r io.Reader
+}
+
+func (f *base64Filter) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		kept := 0
+		for _, c := range p[:n] {
+			if c != ' ' && c != '\t' {
+				p[kept] = c
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// toUTF8 converts text in charset to UTF-8.
+func toUTF8(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		return latin1ToUTF8(content)
+	}
+	return string(content)
+}
+
+func latin1ToUTF8(content []byte) string {
+	var b strings.Builder
+	b.Grow(len(content))
+	for _, c := range content {
+		b.WriteRune(rune(c))
+	}
+	return b.String()
+}
+
+// charsetReader lets mime.WordDecoder decode ISO-8859-1 encoded words in
+// addition to UTF-8 and US-ASCII.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		content, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(latin1ToUTF8(content)), nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
+
+// addressList parses an address header into formatted addresses.
+func addressList(header mail.Header, key string) ([]string, error) {
+	if header.Get(key) == "" {
+		return nil, nil
+	}
+	addrs, err := (&mail.AddressParser{WordDecoder: &mime.WordDecoder{CharsetReader: charsetReader}}).ParseList(strings.Join(header[key], ", "))
+	if err != nil {
+		return nil, fmt.Errorf("mailbreeze: parse %s header: %w", key, err)
+	}
+	list := make([]string, len(addrs))
+	for i, addr := range addrs {
+		list[i] = formatAddress(addr)
+	}
+	return list, nil
+}
+
+func singleAddress(header mail.Header, key string) (string, error) {
+	list, err := addressList(header, key)
+	if err != nil || len(list) == 0 {
+		return "", err
+	}
+	return list[0], nil
+}
+
+func joinedAddresses(header mail.Header, key string) (string, error) {
+	list, err := addressList(header, key)
+	return strings.Join(list, ", "), err
+}
+
+func bareAddress(addr string) string {
+	if parsed, err := mail.ParseAddress(addr); err == nil {
+		return parsed.Address
+	}
+	return addr
+}
+
+// formatAddress formats addr as "Name <address>", quoting the name only when
+// needed. Unlike mail.Address.String, non-ASCII names are not encoded.
+func formatAddress(addr *mail.Address) string {
+	if addr.Name == "" {
+		return addr.Address
+	}
+	name := addr.Name
+	if strings.ContainsAny(name, "\"(),.:;<>@[\\]") {
+		name = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+	}
+	return name + " <" + addr.Address + ">"
+}
@@ -0,0 +1,239 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const rawTestMessage = "From: \"Doe, Jane\" <jane@example.com>\r\n" +
+	"To: Bob <bob@example.com>, carol@example.com\r\n" +
+	"Cc: dave@example.com\r\n" +
+	"Reply-To: support@example.com\r\n" +
+	"Subject: =?UTF-8?B?SGVsbG8g8J+Riw==?=\r\n" +
+	"Date: Mon, 2 Jan 2006 15:04:05 -0700\r\n" +
+	"Message-ID: <abc@example.com>\r\n" +
+	"X-Campaign: spring\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/related; boundary=related\r\n" +
+	"\r\n" +
+	"--related\r\n" +
+	"Content-Type: multipart/alternative; boundary=alt\r\n" +
+	"\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Caf=E9 opens at 9=\r\n" +
+	" today\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Café</p><img src=\"cid:logo@example.com\">\r\n" +
+	"--alt--\r\n" +
+	"--related\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-ID: <logo@example.com>\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw0KGgo=\r\n" +
+	"--related--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"ignored.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"invoice.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBE\r\n" +
+	"Ri0x\r\n" +
+	"--outer--\r\n"
+
+func TestParseRawMessage(t *testing.T) {
+	msg, err := ParseRawMessage(strings.NewReader(rawTestMessage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := msg.Params
+	if p.From != `"Doe, Jane" <jane@example.com>` {
+		t.Errorf("unexpected From %q", p.From)
+	}
+	if len(p.To) != 2 || p.To[0] != "Bob <bob@example.com>" || p.To[1] != "carol@example.com" {
+		t.Errorf("unexpected To %q", p.To)
+	}
+	if len(p.CC) != 1 || p.CC[0] != "dave@example.com" || p.BCC != nil {
+		t.Errorf("unexpected CC %q / BCC %q", p.CC, p.BCC)
+	}
+	if p.ReplyTo != "support@example.com" {
+		t.Errorf("unexpected ReplyTo %q", p.ReplyTo)
+	}
+	if p.Subject != "Hello \U0001F44B" {
+		t.Errorf("unexpected Subject %q", p.Subject)
+	}
+	if len(p.Headers) != 1 || p.Headers["X-Campaign"] != "spring" {
+		t.Errorf("unexpected Headers %v", p.Headers)
+	}
+	if p.Text != "Café opens at 9 today" {
+		t.Errorf("unexpected Text %q", p.Text)
+	}
+	if p.HTML != "<p>Café</p><img src=\"cid:logo@example.com\">" {
+		t.Errorf("unexpected HTML %q", p.HTML)
+	}
+
+	if len(msg.Attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(msg.Attachments))
+	}
+	logo := msg.Attachments[0]
+	if !logo.Inline || logo.ContentID != "logo@example.com" || logo.ContentType != "image/png" ||
+		!strings.HasPrefix(logo.Filename, "attachment-1") || string(logo.Content[1:4]) != "PNG" {
+		t.Errorf("unexpected inline part %+v", logo)
+	}
+	invoice := msg.Attachments[1]
+	if invoice.Inline || invoice.Filename != "invoice.pdf" || string(invoice.Content) != "%PDF-1" {
+		t.Errorf("unexpected attachment %+v", invoice)
+	}
+}
+
+func TestParseRawMessageSinglePart(t *testing.T) {
+	msg, err := ParseRawMessage(strings.NewReader("From: a@example.com\nTo: b@example.com\nSubject: Hi\n\nJust text.\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Params.Text != "Just text.\n" || msg.Params.HTML != "" || len(msg.Attachments) != 0 {
+		t.Errorf("unexpected message %+v", msg)
+	}
+}
+
+func TestParseRawMessageErrors(t *testing.T) {
+	if _, err := ParseRawMessage(strings.NewReader("not a message")); err == nil {
+		t.Error("expected error for missing header terminator")
+	}
+	if _, err := ParseRawMessage(strings.NewReader("From: <<bad\n\nx")); err == nil {
+		t.Error("expected error for invalid From")
+	}
+	if _, err := ParseRawMessage(strings.NewReader("Content-Type: multipart/mixed\n\nx")); err == nil {
+		t.Error("expected error for multipart without boundary")
+	}
+}
+
+func TestEmailsSendRaw(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	uploads := map[string]string{}
+	var sent SendEmailParams
+	var created []CreateUploadParams
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		respond := func(data interface{}) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data})
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/attachments/presigned-url":
+			var params CreateUploadParams
+			json.NewDecoder(r.Body).Decode(&params)
+			created = append(created, params)
+			id := "att_" + params.Filename
+			respond(map[string]interface{}{"attachmentId": id, "uploadUrl": server.URL + "/upload/" + id})
+		case strings.HasPrefix(r.URL.Path, "/upload/"):
+			if r.Header.Get("X-API-Key") != "" {
+				t.Error("expected API key not to be sent to the upload URL")
+			}
+			body, _ := io.ReadAll(r.Body)
+			uploads[strings.TrimPrefix(r.URL.Path, "/upload/")] = string(body)
+		case strings.HasSuffix(r.URL.Path, "/confirm"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/attachments/"), "/confirm")
+			respond(map[string]interface{}{"id": id, "status": "ready"})
+		case r.URL.Path == "/api/v1/emails":
+			json.NewDecoder(r.Body).Decode(&sent)
+			respond(map[string]interface{}{"messageId": "msg_raw"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+	result, err := client.Emails.SendRaw(context.Background(), strings.NewReader(rawTestMessage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MessageID != "msg_raw" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if len(created) != 2 || !created[0].Inline || created[0].ContentID != "logo@example.com" || created[1].Size != 6 {
+		t.Errorf("unexpected uploads %+v", created)
+	}
+	if uploads["att_invoice.pdf"] != "%PDF-1" {
+		t.Errorf("unexpected uploaded content %q", uploads)
+	}
+	if len(sent.AttachmentIDs) != 2 || sent.AttachmentIDs[1] != "att_invoice.pdf" {
+		t.Errorf("unexpected attachment IDs %v", sent.AttachmentIDs)
+	}
+	if sent.Subject != "Hello \U0001F44B" || sent.Headers["X-Campaign"] != "spring" {
+		t.Errorf("unexpected params %+v", sent)
+	}
+	if last := calls[len(calls)-1]; last != "POST /api/v1/emails" {
+		t.Errorf("expected send last, got %v", calls)
+	}
+}
+
+func TestEmailsSendRawUploadFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/attachments/presigned-url":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"attachmentId": "att_1", "uploadUrl": "http://" + r.Host + "/upload"},
+			})
+		case r.URL.Path == "/upload":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+	_, err := client.Emails.SendRaw(context.Background(), strings.NewReader(rawTestMessage))
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 Error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "upload attachment-1.png") {
+		t.Errorf("expected error to name the attachment, got %v", err)
+	}
+}
+
+func TestRawMessageSetEnvelope(t *testing.T) {
+	msg := &RawMessage{Params: SendEmailParams{
+		To:  []string{"Bob <BOB@example.com>", "gone@example.com"},
+		CC:  []string{"carol@example.com"},
+		BCC: []string{"header-bcc@example.com"},
+	}}
+	msg.SetEnvelope("sender@example.com", []string{"bob@example.com", "carol@example.com", "dan@example.com"})
+
+	p := msg.Params
+	if len(p.To) != 1 || p.To[0] != "Bob <BOB@example.com>" {
+		t.Errorf("unexpected To %q", p.To)
+	}
+	if len(p.CC) != 1 || len(p.BCC) != 1 || p.BCC[0] != "dan@example.com" {
+		t.Errorf("unexpected CC %q / BCC %q", p.CC, p.BCC)
+	}
+	if p.From != "sender@example.com" {
+		t.Errorf("expected envelope sender as From, got %q", p.From)
+	}
+}
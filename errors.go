@@ -3,6 +3,7 @@ package mailbreeze
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // Error represents an API error.
@@ -34,6 +35,32 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("mailbreeze: %s (code: %s, status: %d)", e.Message, e.Code, e.StatusCode)
 }
 
+// FieldError describes an invalid field found by local validation.
+type FieldError struct {
+	// Field is the name of the invalid field.
+	Field string
+
+	// Message describes why the field is invalid.
+	Message string
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// FieldErrors is the list of invalid fields found by local validation.
+type FieldErrors []FieldError
+
+// Error implements the error interface.
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return "mailbreeze: invalid fields: " + strings.Join(messages, "; ")
+}
+
 // newError creates a new Error.
 func newError(statusCode int, message, code, requestID string, retryAfter int, details map[string]interface{}) *Error {
 	return &Error{
@@ -65,6 +92,8 @@ func codeFromStatus(statusCode int) string {
 		return "FORBIDDEN"
 	case http.StatusNotFound:
 		return "NOT_FOUND"
+	case http.StatusConflict:
+		return "CONFLICT"
 	case http.StatusTooManyRequests:
 		return "RATE_LIMIT_EXCEEDED"
 	default:
@@ -99,6 +128,14 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// IsConflictError returns true if the error is a conflict error, such as a duplicate contact.
+func IsConflictError(err error) bool {
+	if e, ok := err.(*Error); ok {
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
 // IsRateLimitError returns true if the error is a rate limit error.
 func IsRateLimitError(err error) bool {
 	if e, ok := err.(*Error); ok {
@@ -82,6 +82,15 @@ func TestIsNotFoundError(t *testing.T) {
 	}
 }
 
+func TestIsConflictError(t *testing.T) {
+	if !IsConflictError(&Error{StatusCode: http.StatusConflict}) {
+		t.Error("expected true for 409 status")
+	}
+	if IsConflictError(&Error{StatusCode: http.StatusBadRequest}) {
+		t.Error("expected false for 400 status")
+	}
+}
+
 func TestIsRateLimitError(t *testing.T) {
 	if !IsRateLimitError(&Error{StatusCode: http.StatusTooManyRequests}) {
 		t.Error("expected true for 429 status")
@@ -123,6 +132,7 @@ func TestCodeFromStatus(t *testing.T) {
 		{http.StatusUnauthorized, "AUTHENTICATION_ERROR"},
 		{http.StatusForbidden, "FORBIDDEN"},
 		{http.StatusNotFound, "NOT_FOUND"},
+		{http.StatusConflict, "CONFLICT"},
 		{http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED"},
 		{http.StatusInternalServerError, "SERVER_ERROR"},
 		{http.StatusServiceUnavailable, "SERVER_ERROR"},
@@ -96,6 +96,29 @@ func (c *HTTPClient) Delete(ctx context.Context, path string) error {
 	return c.request(ctx, http.MethodDelete, path, nil, nil, nil, nil)
 }
 
+// upload PUTs content to a pre-signed upload URL. The API key is not sent,
+// since the URL is not an API endpoint.
+func (c *HTTPClient) upload(ctx context.Context, uploadURL, contentType string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "mailbreeze-go/"+Version)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newErrorFromStatus(resp.StatusCode, "upload failed: "+resp.Status, "", "", 0)
+	}
+	return nil
+}
+
 func (c *HTTPClient) request(
 	ctx context.Context,
 	method, path string,
@@ -148,8 +171,7 @@ func (c *HTTPClient) request(
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("request failed: %w", err)
-			if attempt < maxAttempts {
-				time.Sleep(c.retryDelay(attempt, nil))
+			if attempt < maxAttempts && ctx.Err() == nil && sleepContext(ctx, c.retryDelay(attempt, nil)) == nil {
 				continue
 			}
 			return lastErr
@@ -166,7 +188,9 @@ func (c *HTTPClient) request(
 			if !c.isRetryable(apiErr) || attempt >= maxAttempts {
 				return apiErr
 			}
-			time.Sleep(c.retryDelay(attempt, apiErr))
+			if err := sleepContext(ctx, c.retryDelay(attempt, apiErr)); err != nil {
+				return apiErr
+			}
 			continue
 		}
 
@@ -176,6 +200,19 @@ func (c *HTTPClient) request(
 	return lastErr
 }
 
+// sleepContext waits for d, returning early with the error of ctx if it is
+// done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func (c *HTTPClient) setHeaders(req *http.Request, opts *requestOptions) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-API-Key", c.apiKey)
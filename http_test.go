@@ -227,6 +227,33 @@ func TestHTTPClientRetry(t *testing.T) {
 	}
 }
 
+func TestHTTPClientRetryStopsWhenContextDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   map[string]interface{}{"message": "Unavailable"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMaxRetries(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Emails.Get(ctx, "test")
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected retries to stop when the context is done, took %v", elapsed)
+	}
+}
+
 func TestHTTPClientNoRetryOn400(t *testing.T) {
 	attempts := 0
 
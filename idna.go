@@ -0,0 +1,143 @@
+package mailbreeze
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Punycode parameters from RFC 3492.
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+var errPunycodeOverflow = errors.New("punycode overflow")
+
+// domainToASCII converts an internationalized domain name to its ASCII
+// (xn--) form. ASCII labels are only lowercased.
+func domainToASCII(domain string) (string, error) {
+	if !utf8.ValidString(domain) {
+		return "", errors.New("invalid UTF-8 in domain")
+	}
+
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := punycodeEncode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = "xn--" + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// punycodeEncode encodes a Unicode label using the Punycode algorithm (RFC 3492).
+func punycodeEncode(label string) (string, error) {
+	runes := []rune(label)
+
+	var out []byte
+	for _, r := range runes {
+		if r < 0x80 {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out)
+	handled := basic
+	if basic > 0 {
+		out = append(out, '-')
+	}
+
+	n := punycodeInitialN
+	delta := 0
+	bias := punycodeInitialBias
+
+	for handled < len(runes) {
+		m := int(utf8.MaxRune) + 1
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+
+		if (m - n) > (1<<31-1-delta)/(handled+1) {
+			return "", errPunycodeOverflow
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) != n {
+				continue
+			}
+
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := k - bias
+				if t < punycodeTMin {
+					t = punycodeTMin
+				} else if t > punycodeTMax {
+					t = punycodeTMax
+				}
+				if q < t {
+					break
+				}
+				out = append(out, punycodeDigit(t+(q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+			out = append(out, punycodeDigit(q))
+
+			bias = punycodeAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+
+		delta++
+		n++
+	}
+
+	return string(out), nil
+}
+
+func punycodeAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
+
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
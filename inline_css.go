@@ -0,0 +1,183 @@
+package mailbreeze
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/MailBreeze/mailbreeze-go/internal/css"
+	"github.com/MailBreeze/mailbreeze-go/internal/markup"
+)
+
+// BodyTransformer rewrites the body of an email before it is sent, such as
+// InlineCSSBody. Transformers receive a copy of the caller's params.
+type BodyTransformer func(params *SendEmailParams) error
+
+// InlineCSSBody is a BodyTransformer that inlines the stylesheets of the HTML
+// body with InlineCSS:
+//
+//	client := mailbreeze.NewClient(apiKey, mailbreeze.WithBodyTransformer(mailbreeze.InlineCSSBody))
+func InlineCSSBody(params *SendEmailParams) error {
+	if params.HTML != "" {
+		params.HTML = InlineCSS(params.HTML)
+	}
+	return nil
+}
+
+// InlineCSS applies the rules of the <style> elements in an HTML body to the
+// style attributes of the elements they match, since many mail clients
+// ignore stylesheets.
+//
+// Declarations are applied in cascade order: by importance, then specificity,
+// then source order, with existing style attributes winning over normal
+// stylesheet declarations. Rules that cannot be inlined, such as @media
+// queries and :hover selectors, are kept in a <style> element in the head.
+// Style elements with a data-embed attribute are left untouched.
+func InlineCSS(html string) string {
+	doc := markup.Parse(html)
+
+	var styles []*markup.Node
+	doc.Walk(func(n *markup.Node) bool {
+		if n.Type == markup.ElementNode && n.Data == "style" {
+			if _, embed := n.GetAttr("data-embed"); !embed {
+				styles = append(styles, n)
+			}
+			return false
+		}
+		return true
+	})
+	if len(styles) == 0 {
+		return html
+	}
+
+	var rules []inlineRule
+	var kept []string
+	for _, style := range styles {
+		var text strings.Builder
+		for _, child := range style.Children {
+			text.WriteString(child.Data)
+		}
+		sheet := css.Parse(text.String())
+		style.Parent.RemoveChild(style)
+
+		for _, rule := range sheet.Rules {
+			for _, text := range rule.Selectors {
+				sel, err := css.Compile(text)
+				if err != nil {
+					kept = append(kept, formatRule(text, rule.Declarations))
+					continue
+				}
+				rules = append(rules, inlineRule{selector: sel, declarations: rule.Declarations})
+			}
+		}
+		kept = append(kept, sheet.AtRules...)
+	}
+
+	// Stable, so rules of equal specificity keep their source order
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].selector.Specificity().Less(rules[j].selector.Specificity())
+	})
+
+	doc.Walk(func(n *markup.Node) bool {
+		if n.Type != markup.ElementNode {
+			return true
+		}
+		if n.Data == "head" {
+			return false
+		}
+
+		var matched []css.Declaration
+		for _, rule := range rules {
+			if rule.selector.Match(n) {
+				matched = append(matched, rule.declarations...)
+			}
+		}
+		if len(matched) > 0 {
+			existing, _ := n.GetAttr("style")
+			n.SetAttr("style", cascade(matched, css.ParseDeclarations(existing)))
+		}
+		return true
+	})
+
+	if len(kept) > 0 {
+		style := &markup.Node{Type: markup.ElementNode, Data: "style"}
+		style.AppendChild(&markup.Node{Type: markup.TextNode, Data: "\n" + strings.Join(kept, "\n") + "\n"})
+		insertStyle(doc, style)
+	}
+
+	return markup.Render(doc)
+}
+
+type inlineRule struct {
+	selector     *css.Selector
+	declarations []css.Declaration
+}
+
+// cascade merges matched stylesheet declarations, in ascending specificity,
+// with an element's inline declarations and returns the style attribute.
+func cascade(matched, inline []css.Declaration) string {
+	var result []css.Declaration
+	apply := func(decls []css.Declaration, important bool) {
+		for _, decl := range decls {
+			if decl.Important != important {
+				continue
+			}
+			// A later declaration moves to the end, so shorthands and
+			// longhands keep their relative order.
+			for i := range result {
+				if result[i].Property == decl.Property {
+					result = append(result[:i], result[i+1:]...)
+					break
+				}
+			}
+			result = append(result, decl)
+		}
+	}
+	apply(matched, false)
+	apply(inline, false)
+	apply(matched, true)
+	apply(inline, true)
+
+	parts := make([]string, len(result))
+	for i, decl := range result {
+		parts[i] = decl.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatRule(selector string, decls []css.Declaration) string {
+	parts := make([]string, len(decls))
+	for i, decl := range decls {
+		parts[i] = decl.String()
+	}
+	return selector + " { " + strings.Join(parts, "; ") + " }"
+}
+
+// insertStyle adds style to the head of doc, adding a head if the document
+// has an html element but no head. In a fragment, style is added first.
+func insertStyle(doc, style *markup.Node) {
+	if head := doc.Find("head"); head != nil {
+		head.AppendChild(style)
+		return
+	}
+
+	parent := doc
+	if root := doc.Find("html"); root != nil {
+		parent = &markup.Node{Type: markup.ElementNode, Data: "head"}
+		root.InsertBefore(parent, firstChild(root))
+	}
+	var first *markup.Node
+	for _, child := range parent.Children {
+		if child.Type != markup.DoctypeNode {
+			first = child
+			break
+		}
+	}
+	parent.InsertBefore(style, first)
+}
+
+func firstChild(n *markup.Node) *markup.Node {
+	if len(n.Children) == 0 {
+		return nil
+	}
+	return n.Children[0]
+}
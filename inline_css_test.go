@@ -0,0 +1,129 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInlineCSS(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "type and class rules",
+			in:   `<style>p { color: red } .note { font-size: 12px }</style><p class="note">Hi</p><p>There</p>`,
+			want: `<p class="note" style="color: red; font-size: 12px">Hi</p><p style="color: red">There</p>`,
+		},
+		{
+			name: "specificity beats source order",
+			in:   `<style>#a { color: blue } .b { color: green } p { color: red }</style><p id="a" class="b">x</p>`,
+			want: `<p id="a" class="b" style="color: blue">x</p>`,
+		},
+		{
+			name: "later rule wins at equal specificity",
+			in:   `<style>.a { color: red } .b { color: green }</style><p class="b a">x</p>`,
+			want: `<p class="b a" style="color: green">x</p>`,
+		},
+		{
+			name: "inline style beats stylesheet",
+			in:   `<style>p { color: red; margin: 0 }</style><p style="color: blue">x</p>`,
+			want: `<p style="margin: 0; color: blue">x</p>`,
+		},
+		{
+			name: "important beats inline style",
+			in:   `<style>p { color: red !important }</style><p style="color: blue">x</p>`,
+			want: `<p style="color: red !important">x</p>`,
+		},
+		{
+			name: "shorthand order is kept",
+			in:   `<style>p.x { margin-top: 4px } p { margin: 0 }</style><p class="x">x</p>`,
+			want: `<p class="x" style="margin: 0; margin-top: 4px">x</p>`,
+		},
+		{
+			name: "descendant and child combinators",
+			in:   `<style>table td { padding: 4px } tr > td.total { font-weight: bold }</style><table><tr><td>a</td><td class="total">b</td></tr></table>`,
+			want: `<table><tr><td style="padding: 4px">a</td><td class="total" style="padding: 4px; font-weight: bold">b</td></tr></table>`,
+		},
+		{
+			name: "media queries kept in head",
+			in:   `<html><head><title>T</title><style>p { color: red } a:hover { color: blue } @media (max-width: 600px) { p { font-size: 14px !important } }</style></head><body><p>x</p><a href="#">y</a></body></html>`,
+			want: "<html><head><title>T</title><style>\na:hover { color: blue }\n@media (max-width: 600px) { p { font-size: 14px !important } }\n</style></head><body><p style=\"color: red\">x</p><a href=\"#\">y</a></body></html>",
+		},
+		{
+			name: "head added when missing",
+			in:   `<html><body><style>@media print { p { display: none } }</style><p>x</p></body></html>`,
+			want: "<html><head><style>\n@media print { p { display: none } }\n</style></head><body><p>x</p></body></html>",
+		},
+		{
+			name: "embedded styles untouched",
+			in:   `<style data-embed>p { color: red }</style><p>x</p>`,
+			want: `<style data-embed>p { color: red }</style><p>x</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InlineCSS(tt.in); got != tt.want {
+				t.Errorf("expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithBodyTransformer(t *testing.T) {
+	var received SendEmailParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"messageId": "msg_123"},
+		})
+	}))
+	defer server.Close()
+
+	addFooter := func(params *SendEmailParams) error {
+		params.HTML += "<p>footer</p>"
+		return nil
+	}
+	client := NewClient("sk_test_123", WithBaseURL(server.URL),
+		WithBodyTransformer(addFooter),
+		WithBodyTransformer(InlineCSSBody),
+	)
+
+	params := &SendEmailParams{
+		From:    "hello@example.com",
+		To:      []string{"user@example.com"},
+		Subject: "Hello",
+		HTML:    `<style>p { color: red }</style><p>Hello</p>`,
+	}
+	if _, err := client.Emails.Send(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `<p style="color: red">Hello</p><p style="color: red">footer</p>`
+	if received.HTML != want {
+		t.Errorf("expected HTML %s, got %s", want, received.HTML)
+	}
+	if !strings.HasPrefix(params.HTML, "<style>") {
+		t.Errorf("expected caller's params to be unchanged, got %s", params.HTML)
+	}
+}
+
+func TestWithBodyTransformerError(t *testing.T) {
+	client := NewClient("sk_test_123", WithBaseURL("http://127.0.0.1:0"),
+		WithBodyTransformer(func(*SendEmailParams) error { return errors.New("boom") }),
+	)
+
+	_, err := client.Emails.Send(context.Background(), &SendEmailParams{From: "a@example.com"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected transformer error, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+// Package css parses the subset of CSS needed to inline stylesheets into
+// HTML email: style rules, declarations and selectors, with the at-rules and
+// rules that cannot be inlined kept as raw text.
+package css
+
+import (
+	"strings"
+)
+
+// Declaration is a property and its value.
+type Declaration struct {
+	Property  string
+	Value     string
+	Important bool
+}
+
+// String returns the declaration as written in a style attribute.
+func (d Declaration) String() string {
+	if d.Important {
+		return d.Property + ": " + d.Value + " !important"
+	}
+	return d.Property + ": " + d.Value
+}
+
+// Rule is a style rule: a selector list and its declarations.
+type Rule struct {
+	// Selectors is the raw text of each selector in the selector list.
+	Selectors    []string
+	Declarations []Declaration
+}
+
+// Stylesheet is a parsed stylesheet.
+type Stylesheet struct {
+	Rules []Rule
+
+	// AtRules are the raw text of at-rules such as @media and @font-face, in
+	// source order.
+	AtRules []string
+}
+
+// Parse parses a stylesheet. Invalid parts are skipped.
+func Parse(s string) *Stylesheet {
+	s = stripComments(s)
+	sheet := &Stylesheet{}
+
+	for i := 0; i < len(s); {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+
+		if s[i] == '@' {
+			end := atRuleEnd(s, i)
+			sheet.AtRules = append(sheet.AtRules, strings.TrimSpace(s[i:end]))
+			i = end
+			continue
+		}
+
+		open := indexOutsideQuotes(s[i:], '{')
+		if open < 0 {
+			break
+		}
+		close := matchingBrace(s, i+open)
+		selectors := SplitList(s[i : i+open])
+		body := s[i+open+1 : close]
+		i = close + 1
+
+		if len(selectors) == 0 {
+			continue
+		}
+		sheet.Rules = append(sheet.Rules, Rule{Selectors: selectors, Declarations: ParseDeclarations(body)})
+	}
+	return sheet
+}
+
+// ParseDeclarations parses a declaration block, such as a style attribute.
+func ParseDeclarations(s string) []Declaration {
+	var decls []Declaration
+	for _, part := range splitOutside(s, ';') {
+		colon := strings.IndexByte(part, ':')
+		if colon < 0 {
+			continue
+		}
+		property := strings.ToLower(strings.TrimSpace(part[:colon]))
+		value := strings.TrimSpace(part[colon+1:])
+		if property == "" || value == "" {
+			continue
+		}
+
+		important := false
+		if bang := strings.LastIndexByte(value, '!'); bang >= 0 &&
+			strings.EqualFold(strings.TrimSpace(value[bang+1:]), "important") {
+			important = true
+			value = strings.TrimSpace(value[:bang])
+		}
+		decls = append(decls, Declaration{Property: property, Value: value, Important: important})
+	}
+	return decls
+}
+
+// SplitList splits a comma-separated selector list, ignoring commas inside
+// parentheses, brackets and quotes.
+func SplitList(s string) []string {
+	var list []string
+	for _, part := range splitOutside(s, ',') {
+		if part = strings.TrimSpace(part); part != "" {
+			list = append(list, part)
+		}
+	}
+	return list
+}
+
+// splitOutside splits s on sep outside parentheses, brackets and quotes.
+func splitOutside(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// atRuleEnd returns the index just past the at-rule starting at i: after its
+// block, or after its terminating semicolon.
+func atRuleEnd(s string, i int) int {
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case ';':
+			return j + 1
+		case '{':
+			end := matchingBrace(s, j)
+			if end < len(s) {
+				return end + 1
+			}
+			return len(s)
+		case '"', '\'':
+			if k := strings.IndexByte(s[j+1:], s[j]); k >= 0 {
+				j += k + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+// matchingBrace returns the index of the brace closing the one at open, or
+// len(s) if it is not closed.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
+func indexOutsideQuotes(s string, c byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+func stripComments(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "/*")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:start])
+		end := strings.Index(s[start+2:], "*/")
+		if end < 0 {
+			return b.String()
+		}
+		s = s[start+2+end+2:]
+	}
+}
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
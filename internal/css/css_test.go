@@ -0,0 +1,56 @@
+package css
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	sheet := Parse(`
+		/* reset */
+		p, .lead { color: red; margin: 0 !important }
+		@import url("fonts.css");
+		@media (max-width: 600px) { .lead { font-size: 14px } }
+		a[title="a,b"] { content: "x;y" }
+	`)
+
+	if len(sheet.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %+v", sheet.Rules)
+	}
+	if !reflect.DeepEqual(sheet.Rules[0].Selectors, []string{"p", ".lead"}) {
+		t.Errorf("unexpected selectors %q", sheet.Rules[0].Selectors)
+	}
+	want := []Declaration{
+		{Property: "color", Value: "red"},
+		{Property: "margin", Value: "0", Important: true},
+	}
+	if !reflect.DeepEqual(sheet.Rules[0].Declarations, want) {
+		t.Errorf("expected %+v, got %+v", want, sheet.Rules[0].Declarations)
+	}
+	if sel := sheet.Rules[1].Selectors; len(sel) != 1 || sel[0] != `a[title="a,b"]` {
+		t.Errorf("unexpected selectors %q", sel)
+	}
+	if decls := sheet.Rules[1].Declarations; len(decls) != 1 || decls[0].Value != `"x;y"` {
+		t.Errorf("unexpected declarations %+v", decls)
+	}
+
+	wantAt := []string{`@import url("fonts.css");`, `@media (max-width: 600px) { .lead { font-size: 14px } }`}
+	if !reflect.DeepEqual(sheet.AtRules, wantAt) {
+		t.Errorf("expected at-rules %q, got %q", wantAt, sheet.AtRules)
+	}
+}
+
+func TestParseDeclarations(t *testing.T) {
+	decls := ParseDeclarations(`COLOR: Blue; background: url(a;b.png) ; bogus; width:; font-weight: bold ! IMPORTANT`)
+	want := []Declaration{
+		{Property: "color", Value: "Blue"},
+		{Property: "background", Value: "url(a;b.png)"},
+		{Property: "font-weight", Value: "bold", Important: true},
+	}
+	if !reflect.DeepEqual(decls, want) {
+		t.Errorf("expected %+v, got %+v", want, decls)
+	}
+	if got := want[2].String(); got != "font-weight: bold !important" {
+		t.Errorf("unexpected string %q", got)
+	}
+}
@@ -0,0 +1,515 @@
+package css
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/MailBreeze/mailbreeze-go/internal/markup"
+)
+
+// ErrUnsupported is returned by Compile for selectors that cannot be matched
+// against a static document, such as :hover or ::before, and for syntax it
+// does not understand.
+var ErrUnsupported = errors.New("css: unsupported selector")
+
+// Specificity is the (id, class, type) specificity of a selector.
+type Specificity [3]int
+
+// Less reports whether s is less specific than o.
+func (s Specificity) Less(o Specificity) bool {
+	for i := range s {
+		if s[i] != o[i] {
+			return s[i] < o[i]
+		}
+	}
+	return false
+}
+
+// Selector is a compiled complex selector.
+type Selector struct {
+	// compounds are matched right to left; combinators[i] joins
+	// compounds[i] and compounds[i+1].
+	compounds   []compound
+	combinators []byte
+	specificity Specificity
+}
+
+// Specificity returns the specificity of the selector.
+func (s *Selector) Specificity() Specificity {
+	return s.specificity
+}
+
+type compound struct {
+	tag     string // "" matches any element
+	ids     []string
+	classes []string
+	attrs   []attrSelector
+	pseudos []pseudoClass
+}
+
+type attrSelector struct {
+	name     string
+	op       string
+	value    string
+	foldCase bool
+}
+
+type pseudoClass struct {
+	name string
+	a, b int       // for the nth- pseudo-classes
+	not  *compound // for :not()
+}
+
+// Compile compiles a single selector, such as "ul > li.active a[href]".
+func Compile(s string) (*Selector, error) {
+	sel := &Selector{}
+	p := &selectorParser{s: strings.TrimSpace(s)}
+	if p.s == "" {
+		return nil, ErrUnsupported
+	}
+
+	for {
+		c, err := p.compound(&sel.specificity)
+		if err != nil {
+			return nil, err
+		}
+		sel.compounds = append(sel.compounds, c)
+
+		sawSpace := p.skipSpace()
+		if p.done() {
+			return sel, nil
+		}
+		switch comb := p.s[p.i]; comb {
+		case '>', '+', '~':
+			p.i++
+			p.skipSpace()
+			sel.combinators = append(sel.combinators, comb)
+		default:
+			if !sawSpace {
+				return nil, ErrUnsupported
+			}
+			sel.combinators = append(sel.combinators, ' ')
+		}
+	}
+}
+
+// Match reports whether n is an element matched by the selector.
+func (s *Selector) Match(n *markup.Node) bool {
+	return s.matchAt(len(s.compounds)-1, n)
+}
+
+func (s *Selector) matchAt(i int, n *markup.Node) bool {
+	if !s.compounds[i].match(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	switch s.combinators[i-1] {
+	case '>':
+		parent := parentElement(n)
+		return parent != nil && s.matchAt(i-1, parent)
+	case '+':
+		prev := previousElement(n)
+		return prev != nil && s.matchAt(i-1, prev)
+	case '~':
+		for prev := previousElement(n); prev != nil; prev = previousElement(prev) {
+			if s.matchAt(i-1, prev) {
+				return true
+			}
+		}
+	default:
+		for parent := parentElement(n); parent != nil; parent = parentElement(parent) {
+			if s.matchAt(i-1, parent) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *compound) match(n *markup.Node) bool {
+	if n == nil || n.Type != markup.ElementNode {
+		return false
+	}
+	if c.tag != "" && c.tag != n.Data {
+		return false
+	}
+	for _, id := range c.ids {
+		if v, _ := n.GetAttr("id"); v != id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		class, _ := n.GetAttr("class")
+		classes := strings.Fields(class)
+		for _, want := range c.classes {
+			if !containsString(classes, want) {
+				return false
+			}
+		}
+	}
+	for _, attr := range c.attrs {
+		if !attr.match(n) {
+			return false
+		}
+	}
+	for _, pseudo := range c.pseudos {
+		if !pseudo.match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a attrSelector) match(n *markup.Node) bool {
+	v, ok := n.GetAttr(a.name)
+	if !ok {
+		return false
+	}
+	want := a.value
+	if a.foldCase {
+		v, want = strings.ToLower(v), strings.ToLower(want)
+	}
+
+	switch a.op {
+	case "":
+		return true
+	case "=":
+		return v == want
+	case "~=":
+		return containsString(strings.Fields(v), want)
+	case "|=":
+		return v == want || strings.HasPrefix(v, want+"-")
+	case "^=":
+		return want != "" && strings.HasPrefix(v, want)
+	case "$=":
+		return want != "" && strings.HasSuffix(v, want)
+	case "*=":
+		return want != "" && strings.Contains(v, want)
+	}
+	return false
+}
+
+func (p pseudoClass) match(n *markup.Node) bool {
+	switch p.name {
+	case "not":
+		return !p.not.match(n)
+	case "root":
+		return n.Parent != nil && n.Parent.Type == markup.DocumentNode
+	case "empty":
+		for _, child := range n.Children {
+			if child.Type == markup.ElementNode || (child.Type == markup.TextNode && child.Data != "") {
+				return false
+			}
+		}
+		return true
+	case "first-child":
+		return previousElement(n) == nil
+	case "last-child":
+		return nextElement(n) == nil
+	case "only-child":
+		return previousElement(n) == nil && nextElement(n) == nil
+	case "first-of-type":
+		return position(n, false, true) == 1
+	case "last-of-type":
+		return position(n, true, true) == 1
+	case "only-of-type":
+		return position(n, false, true) == 1 && position(n, true, true) == 1
+	case "nth-child":
+		return nthMatch(p.a, p.b, position(n, false, false))
+	case "nth-last-child":
+		return nthMatch(p.a, p.b, position(n, true, false))
+	case "nth-of-type":
+		return nthMatch(p.a, p.b, position(n, false, true))
+	case "nth-last-of-type":
+		return nthMatch(p.a, p.b, position(n, true, true))
+	}
+	return false
+}
+
+// nthMatch reports whether the 1-based position k is an+b for some n >= 0.
+func nthMatch(a, b, k int) bool {
+	if a == 0 {
+		return k == b
+	}
+	diff := k - b
+	return diff%a == 0 && diff/a >= 0
+}
+
+// position returns the 1-based position of n among its element siblings,
+// counting from the end if fromEnd, and only siblings with the same tag if
+// ofType.
+func position(n *markup.Node, fromEnd, ofType bool) int {
+	if n.Parent == nil {
+		return 1
+	}
+	siblings := n.Parent.Children
+	pos := 0
+	for i := range siblings {
+		sibling := siblings[i]
+		if fromEnd {
+			sibling = siblings[len(siblings)-1-i]
+		}
+		if sibling.Type != markup.ElementNode || (ofType && sibling.Data != n.Data) {
+			continue
+		}
+		pos++
+		if sibling == n {
+			return pos
+		}
+	}
+	return pos
+}
+
+func parentElement(n *markup.Node) *markup.Node {
+	if p := n.Parent; p != nil && p.Type == markup.ElementNode {
+		return p
+	}
+	return nil
+}
+
+func previousElement(n *markup.Node) *markup.Node {
+	return siblingElement(n, -1)
+}
+
+func nextElement(n *markup.Node) *markup.Node {
+	return siblingElement(n, 1)
+}
+
+func siblingElement(n *markup.Node, step int) *markup.Node {
+	if n.Parent == nil {
+		return nil
+	}
+	siblings := n.Parent.Children
+	for i, sibling := range siblings {
+		if sibling != n {
+			continue
+		}
+		for j := i + step; j >= 0 && j < len(siblings); j += step {
+			if siblings[j].Type == markup.ElementNode {
+				return siblings[j]
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorParser parses one selector from s.
+type selectorParser struct {
+	s string
+	i int
+}
+
+func (p *selectorParser) done() bool { return p.i >= len(p.s) }
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.i
+	for !p.done() && isSpace(p.s[p.i]) {
+		p.i++
+	}
+	return p.i > start
+}
+
+func (p *selectorParser) ident() string {
+	start := p.i
+	for !p.done() {
+		c := p.s[p.i]
+		if c == '-' || c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			p.i++
+			continue
+		}
+		break
+	}
+	return p.s[start:p.i]
+}
+
+// compound parses a compound selector and adds its specificity to spec.
+func (p *selectorParser) compound(spec *Specificity) (compound, error) {
+	var c compound
+	start := p.i
+
+	if !p.done() && p.s[p.i] == '*' {
+		p.i++
+	} else if tag := p.ident(); tag != "" {
+		c.tag = strings.ToLower(tag)
+		spec[2]++
+	}
+
+	for !p.done() {
+		switch p.s[p.i] {
+		case '#':
+			p.i++
+			id := p.ident()
+			if id == "" {
+				return c, ErrUnsupported
+			}
+			c.ids = append(c.ids, id)
+			spec[0]++
+		case '.':
+			p.i++
+			class := p.ident()
+			if class == "" {
+				return c, ErrUnsupported
+			}
+			c.classes = append(c.classes, class)
+			spec[1]++
+		case '[':
+			attr, err := p.attr()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, attr)
+			spec[1]++
+		case ':':
+			pseudo, err := p.pseudo(spec)
+			if err != nil {
+				return c, err
+			}
+			c.pseudos = append(c.pseudos, pseudo)
+		default:
+			if p.i == start {
+				return c, ErrUnsupported
+			}
+			return c, nil
+		}
+	}
+	if p.i == start {
+		return c, ErrUnsupported
+	}
+	return c, nil
+}
+
+// attr parses an attribute selector such as [href^="https:" i].
+func (p *selectorParser) attr() (attrSelector, error) {
+	end := strings.IndexByte(p.s[p.i:], ']')
+	if end < 0 {
+		return attrSelector{}, ErrUnsupported
+	}
+	inner := strings.TrimSpace(p.s[p.i+1 : p.i+end])
+	p.i += end + 1
+
+	var a attrSelector
+	opAt := strings.IndexAny(inner, "=~|^$*")
+	if opAt < 0 {
+		a.name = strings.ToLower(inner)
+		return a, nil
+	}
+	a.name = strings.ToLower(strings.TrimSpace(inner[:opAt]))
+	rest := inner[opAt:]
+	if strings.HasPrefix(rest, "=") {
+		a.op = "="
+	} else if len(rest) > 1 && rest[1] == '=' {
+		a.op = rest[:2]
+	} else {
+		return a, ErrUnsupported
+	}
+
+	value := strings.TrimSpace(rest[len(a.op):])
+	if lower := strings.ToLower(value); strings.HasSuffix(lower, " i") {
+		a.foldCase = true
+		value = strings.TrimSpace(value[:len(value)-2])
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	a.value = value
+
+	if a.name == "" {
+		return a, ErrUnsupported
+	}
+	return a, nil
+}
+
+// pseudo parses a pseudo-class and adds its specificity to spec. Dynamic
+// pseudo-classes and pseudo-elements are unsupported.
+func (p *selectorParser) pseudo(spec *Specificity) (pseudoClass, error) {
+	p.i++
+	if !p.done() && p.s[p.i] == ':' {
+		return pseudoClass{}, ErrUnsupported
+	}
+	pc := pseudoClass{name: strings.ToLower(p.ident())}
+
+	var arg string
+	if !p.done() && p.s[p.i] == '(' {
+		end := strings.IndexByte(p.s[p.i:], ')')
+		if end < 0 {
+			return pc, ErrUnsupported
+		}
+		arg = strings.TrimSpace(p.s[p.i+1 : p.i+end])
+		p.i += end + 1
+	}
+
+	switch pc.name {
+	case "root", "empty", "first-child", "last-child", "only-child",
+		"first-of-type", "last-of-type", "only-of-type":
+		spec[1]++
+	case "nth-child", "nth-last-child", "nth-of-type", "nth-last-of-type":
+		a, b, ok := parseNth(arg)
+		if !ok {
+			return pc, ErrUnsupported
+		}
+		pc.a, pc.b = a, b
+		spec[1]++
+	case "not":
+		inner := &selectorParser{s: arg}
+		not, err := inner.compound(spec)
+		if err != nil || !inner.done() {
+			return pc, ErrUnsupported
+		}
+		pc.not = &not
+	default:
+		return pc, ErrUnsupported
+	}
+	return pc, nil
+}
+
+// parseNth parses the argument of an nth- pseudo-class: odd, even, or an+b.
+func parseNth(s string) (a, b int, ok bool) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	switch s {
+	case "odd":
+		return 2, 1, true
+	case "even":
+		return 2, 0, true
+	case "":
+		return 0, 0, false
+	}
+
+	n := strings.IndexByte(s, 'n')
+	if n < 0 {
+		b, err := strconv.Atoi(s)
+		return 0, b, err == nil
+	}
+
+	switch coef := s[:n]; coef {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		var err error
+		if a, err = strconv.Atoi(coef); err != nil {
+			return 0, 0, false
+		}
+	}
+	if rest := s[n+1:]; rest != "" {
+		var err error
+		if b, err = strconv.Atoi(rest); err != nil {
+			return 0, 0, false
+		}
+	}
+	return a, b, true
+}
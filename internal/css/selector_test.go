@@ -0,0 +1,110 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MailBreeze/mailbreeze-go/internal/markup"
+)
+
+const selectorDoc = `<html><body>
+<div id="main" class="box wide">
+	<h1 lang="en-US">Title</h1>
+	<p class="lead">One</p>
+	<p>Two <a href="https://example.com/x.pdf" title="Docs Link">doc</a></p>
+	<p>Three</p>
+</div>
+<ul><li>a</li><li>b</li><li>c</li><li>d</li></ul>
+<span></span>
+</body></html>`
+
+// matchTexts returns the tag and text of each element matched by selector.
+func matchTexts(t *testing.T, doc *markup.Node, selector string) []string {
+	t.Helper()
+	sel, err := Compile(selector)
+	if err != nil {
+		t.Fatalf("compile %q: %v", selector, err)
+	}
+	var texts []string
+	doc.Walk(func(n *markup.Node) bool {
+		if sel.Match(n) {
+			texts = append(texts, n.Data+":"+n.Text())
+		}
+		return true
+	})
+	return texts
+}
+
+func TestSelectorMatch(t *testing.T) {
+	doc := markup.Parse(selectorDoc)
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"h1", []string{"h1:Title"}},
+		{"#main > .lead", []string{"p:One"}},
+		{"div.box.wide p:last-child", []string{"p:Three"}},
+		{"body p", []string{"p:One", "p:Two doc", "p:Three"}},
+		{"h1 + p", []string{"p:One"}},
+		{"h1 ~ p:not(.lead)", []string{"p:Two doc", "p:Three"}},
+		{`a[href$=".pdf"][title~=Link]`, []string{"a:doc"}},
+		{`a[title="docs link" i]`, []string{"a:doc"}},
+		{"[lang|=en]", []string{"h1:Title"}},
+		{"li:nth-child(odd)", []string{"li:a", "li:c"}},
+		{"li:nth-child(-n+2)", []string{"li:a", "li:b"}},
+		{"li:nth-last-child(1)", []string{"li:d"}},
+		{"p:first-of-type", []string{"p:One"}},
+		{"span:empty", []string{"span:"}},
+		{"div.missing", nil},
+		{"ul > p", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got := matchTexts(t, doc, tt.selector)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("expected %q, got %q", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSpecificity(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     Specificity
+	}{
+		{"*", Specificity{0, 0, 0}},
+		{"p", Specificity{0, 0, 1}},
+		{"ul li.active", Specificity{0, 1, 2}},
+		{"#main a[href]:first-child", Specificity{1, 2, 1}},
+		{"p:not(#x)", Specificity{1, 0, 1}},
+	}
+	for _, tt := range tests {
+		sel, err := Compile(tt.selector)
+		if err != nil {
+			t.Fatalf("compile %q: %v", tt.selector, err)
+		}
+		if sel.Specificity() != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.selector, tt.want, sel.Specificity())
+		}
+	}
+
+	if !(Specificity{0, 2, 0}).Less(Specificity{1, 0, 0}) || (Specificity{0, 1, 1}).Less(Specificity{0, 1, 0}) {
+		t.Error("unexpected specificity ordering")
+	}
+}
+
+func TestCompileUnsupported(t *testing.T) {
+	for _, selector := range []string{"a:hover", "p::before", "p:before", "li:nth-child(foo)", "", ">", "a[", "p!"} {
+		if _, err := Compile(selector); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%q: expected ErrUnsupported, got %v", selector, err)
+		}
+	}
+}
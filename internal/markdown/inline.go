@@ -0,0 +1,508 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// inlineNode is a piece of rendered inline content. Delimiter runs of * and _
+// and link brackets stay as nodes until emphasis and links are resolved.
+type inlineNode struct {
+	html string
+
+	// Delimiter runs
+	delim     byte
+	count     int
+	orig      int
+	canOpen   bool
+	canClose  bool
+	openTags  string
+	closeTags string
+
+	// Link and image openers
+	image  bool
+	active bool
+	pos    int // index in the source just after the bracket
+}
+
+func (n *inlineNode) render() string {
+	if n.delim == 0 {
+		return n.html
+	}
+	return n.closeTags + strings.Repeat(string(n.delim), n.count) + n.openTags
+}
+
+// inline renders the inline content of a paragraph or heading.
+func (p *parser) inline(s string) string {
+	var nodes []*inlineNode
+	var brackets []int
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &inlineNode{html: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			switch {
+			case i+1 < len(s) && s[i+1] == '\n':
+				text.WriteString("<br>\n")
+				i = skipLeadingSpaces(s, i+2)
+			case i+1 < len(s) && isASCIIPunct(s[i+1]):
+				text.WriteString(escapeHTML(s[i+1 : i+2]))
+				i += 2
+			default:
+				text.WriteByte('\\')
+				i++
+			}
+
+		case '\n':
+			// Two or more trailing spaces make a hard break
+			trimmed := strings.TrimRight(text.String(), " ")
+			spaces := text.Len() - len(trimmed)
+			text.Reset()
+			text.WriteString(trimmed)
+			if spaces >= 2 {
+				text.WriteString("<br>\n")
+			} else {
+				text.WriteByte('\n')
+			}
+			i = skipLeadingSpaces(s, i+1)
+
+		case '`':
+			n := runLength(s, i, '`')
+			if end := closingBackticks(s, i+n, n); end >= 0 {
+				code := strings.ReplaceAll(s[i+n:end], "\n", " ")
+				if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+					code = code[1 : len(code)-1]
+				}
+				text.WriteString("<code>" + escapeHTML(code) + "</code>")
+				i = end + n
+			} else {
+				text.WriteString(s[i : i+n])
+				i += n
+			}
+
+		case '*', '_':
+			n := runLength(s, i, c)
+			before, _ := utf8.DecodeLastRuneInString(s[:i])
+			after, _ := utf8.DecodeRuneInString(s[i+n:])
+			if i == 0 {
+				before = ' '
+			}
+			if i+n >= len(s) {
+				after = ' '
+			}
+			left := !unicode.IsSpace(after) && (!isPunct(after) || unicode.IsSpace(before) || isPunct(before))
+			right := !unicode.IsSpace(before) && (!isPunct(before) || unicode.IsSpace(after) || isPunct(after))
+
+			node := &inlineNode{delim: c, count: n, orig: n, canOpen: left, canClose: right}
+			if c == '_' {
+				node.canOpen = left && (!right || isPunct(before))
+				node.canClose = right && (!left || isPunct(after))
+			}
+			flush()
+			nodes = append(nodes, node)
+			i += n
+
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				flush()
+				brackets = append(brackets, len(nodes))
+				nodes = append(nodes, &inlineNode{html: "![", image: true, active: true, pos: i + 2})
+				i += 2
+			} else {
+				text.WriteByte('!')
+				i++
+			}
+
+		case '[':
+			flush()
+			brackets = append(brackets, len(nodes))
+			nodes = append(nodes, &inlineNode{html: "[", active: true, pos: i + 1})
+			i++
+
+		case ']':
+			if len(brackets) == 0 {
+				text.WriteByte(']')
+				i++
+				continue
+			}
+			at := brackets[len(brackets)-1]
+			brackets = brackets[:len(brackets)-1]
+			opener := nodes[at]
+
+			flush()
+			dest, title, end, ok := p.linkTarget(s, opener.pos, i)
+			if !opener.active || !ok {
+				text.WriteByte(']')
+				i++
+				continue
+			}
+
+			processEmphasis(nodes, at+1)
+			var inner strings.Builder
+			for _, n := range nodes[at+1:] {
+				inner.WriteString(n.render())
+			}
+
+			var link string
+			if opener.image {
+				link = `<img src="` + escapeHTML(safeURL(dest)) + `" alt="` + stripTags(inner.String()) + `"`
+				if title != "" {
+					link += ` title="` + escapeHTML(title) + `"`
+				}
+				link += ">"
+			} else {
+				link = "<a"
+				if href := safeURL(dest); href != "" {
+					link += ` href="` + escapeHTML(href) + `"`
+				}
+				if title != "" {
+					link += ` title="` + escapeHTML(title) + `"`
+				}
+				link += ">" + inner.String() + "</a>"
+
+				// Links may not contain other links
+				for _, b := range brackets {
+					if !nodes[b].image {
+						nodes[b].active = false
+					}
+				}
+			}
+			nodes = append(nodes[:at], &inlineNode{html: link})
+			i = end
+
+		case '<':
+			if link, end, ok := autolink(s, i); ok {
+				text.WriteString(link)
+				i = end
+			} else {
+				text.WriteString("&lt;")
+				i++
+			}
+
+		case '&':
+			if entity, end, ok := entityAt(s, i); ok {
+				text.WriteString(escapeHTML(entity))
+				i = end
+			} else {
+				text.WriteString("&amp;")
+				i++
+			}
+
+		default:
+			text.WriteString(escapeHTML(s[i : i+1]))
+			i++
+		}
+	}
+	flush()
+
+	processEmphasis(nodes, 0)
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(n.render())
+	}
+	return b.String()
+}
+
+// processEmphasis matches the delimiter runs in nodes[bottom:] into em and
+// strong elements, following the CommonMark algorithm.
+func processEmphasis(nodes []*inlineNode, bottom int) {
+	for ci := bottom; ci < len(nodes); ci++ {
+		closer := nodes[ci]
+		if closer.delim == 0 || !closer.canClose {
+			continue
+		}
+
+		for closer.count > 0 {
+			oi := -1
+			for j := ci - 1; j >= bottom; j-- {
+				o := nodes[j]
+				if o.delim != closer.delim || !o.canOpen || o.count == 0 {
+					continue
+				}
+				// The "rule of 3" for runs that can both open and close
+				if (o.canClose || closer.canOpen) && (o.orig+closer.orig)%3 == 0 && (o.orig%3 != 0 || closer.orig%3 != 0) {
+					continue
+				}
+				oi = j
+				break
+			}
+			if oi < 0 {
+				break
+			}
+
+			opener := nodes[oi]
+			use, tag := 1, "em"
+			if opener.count >= 2 && closer.count >= 2 {
+				use, tag = 2, "strong"
+			}
+			opener.openTags = "<" + tag + ">" + opener.openTags
+			closer.closeTags += "</" + tag + ">"
+			opener.count -= use
+			closer.count -= use
+
+			for j := oi + 1; j < ci; j++ {
+				nodes[j].canOpen, nodes[j].canClose = false, false
+			}
+		}
+	}
+}
+
+// linkTarget parses what follows the ] at close: an inline destination and
+// title, or a full, collapsed or shortcut reference. It returns the index
+// after the link.
+func (p *parser) linkTarget(s string, open, close int) (dest, title string, end int, ok bool) {
+	i := close + 1
+	if i < len(s) && s[i] == '(' {
+		j := skipSpaceNewline(s, i+1)
+		if j < len(s) && s[j] == ')' {
+			return "", "", j + 1, true
+		}
+		if dest, k, ok := parseDestination(s, j); ok {
+			k2 := skipSpaceNewline(s, k)
+			if k2 > k && k2 < len(s) && strings.IndexByte(`"'(`, s[k2]) >= 0 {
+				if t, k3, ok := parseTitle(s, k2); ok {
+					title, k2 = t, skipSpaceNewline(s, k3)
+				}
+			}
+			if k2 < len(s) && s[k2] == ')' {
+				return dest, title, k2 + 1, true
+			}
+		}
+	}
+
+	label := s[open:close]
+	end = close + 1
+	if i < len(s) && s[i] == '[' {
+		if j := strings.IndexByte(s[i+1:], ']'); j >= 0 {
+			if ref := s[i+1 : i+1+j]; strings.TrimSpace(ref) != "" {
+				label = ref
+			}
+			end = i + 1 + j + 1
+		}
+	}
+	if ref, found := p.refs[normalizeLabel(label)]; found {
+		return ref.dest, ref.title, end, true
+	}
+	return "", "", 0, false
+}
+
+// parseDestination parses a link destination at s[i:]: <...> or a run of
+// non-space characters with balanced parentheses.
+func parseDestination(s string, i int) (string, int, bool) {
+	if i < len(s) && s[i] == '<' {
+		for j := i + 1; j < len(s); j++ {
+			switch s[j] {
+			case '\\':
+				j++
+			case '\n', '<':
+				return "", i, false
+			case '>':
+				return decodeText(s[i+1 : j]), j + 1, true
+			}
+		}
+		return "", i, false
+	}
+
+	depth := 0
+	j := i
+loop:
+	for ; j < len(s); j++ {
+		switch c := s[j]; {
+		case c == '\\' && j+1 < len(s) && isASCIIPunct(s[j+1]):
+			j++
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		case c <= ' ':
+			break loop
+		}
+	}
+	if j == i || depth != 0 {
+		return "", i, false
+	}
+	return decodeText(s[i:j]), j, true
+}
+
+// parseTitle parses a link title in double quotes, single quotes or
+// parentheses at s[i:].
+func parseTitle(s string, i int) (string, int, bool) {
+	closer := s[i]
+	if closer == '(' {
+		closer = ')'
+	}
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case closer:
+			return decodeText(s[i+1 : j]), j + 1, true
+		}
+	}
+	return "", i, false
+}
+
+// autolink parses <scheme:...> or <address@example.com> at s[i:].
+func autolink(s string, i int) (string, int, bool) {
+	end := strings.IndexByte(s[i:], '>')
+	if end < 0 {
+		return "", i, false
+	}
+	target := s[i+1 : i+end]
+	if target == "" || strings.ContainsAny(target, " <\n") {
+		return "", i, false
+	}
+
+	href := target
+	colon := strings.IndexByte(target, ':')
+	at := strings.IndexByte(target, '@')
+	switch {
+	case colon >= 2 && colon <= 32 && isScheme(target[:colon]):
+	case colon < 0 && at > 0 && at < len(target)-1 && !strings.ContainsAny(target, "\\"):
+		href = "mailto:" + target
+	default:
+		return "", i, false
+	}
+
+	if href = safeURL(href); href == "" {
+		return escapeHTML(target), i + end + 1, true
+	}
+	return `<a href="` + escapeHTML(href) + `">` + escapeHTML(target) + "</a>", i + end + 1, true
+}
+
+// entityAt decodes an HTML entity or numeric character reference at s[i:].
+func entityAt(s string, i int) (string, int, bool) {
+	end := strings.IndexByte(s[i:], ';')
+	if end < 2 || end > 33 {
+		return "", i, false
+	}
+	ref := s[i : i+end+1]
+	decoded := html.UnescapeString(ref)
+	if decoded == ref {
+		return "", i, false
+	}
+	return decoded, i + end + 1, true
+}
+
+// safeURL returns u if it is relative or uses a scheme that is safe in
+// email, and "" otherwise.
+func safeURL(u string) string {
+	u = strings.TrimSpace(u)
+	colon := strings.IndexByte(u, ':')
+	if colon < 0 || strings.IndexAny(u[:colon], "/?#") >= 0 {
+		return u
+	}
+	switch strings.ToLower(u[:colon]) {
+	case "http", "https", "mailto", "tel", "cid":
+		return u
+	}
+	return ""
+}
+
+func isScheme(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		letter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !letter && (i == 0 || !(isDigit(c) || c == '+' || c == '.' || c == '-')) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeText resolves backslash escapes and entities in link destinations
+// and titles.
+func decodeText(s string) string {
+	return html.UnescapeString(unescapeBackslashes(s))
+}
+
+func unescapeBackslashes(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// stripTags returns rendered inline HTML without its tags, for image alt
+// text.
+func stripTags(s string) string {
+	var b strings.Builder
+	inTag := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '<':
+			inTag = true
+		case s[i] == '>' && inTag:
+			inTag = false
+		case !inTag:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+var htmlEscaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&quot;")
+
+func escapeHTML(s string) string {
+	return htmlEscaper.Replace(s)
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// closingBackticks returns the index of the next run of exactly n backticks
+// at or after i, or -1.
+func closingBackticks(s string, i, n int) int {
+	for i < len(s) {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		m := runLength(s, i, '`')
+		if m == n {
+			return i
+		}
+		i += m
+	}
+	return -1
+}
+
+func skipLeadingSpaces(s string, i int) int {
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return i
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
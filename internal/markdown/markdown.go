@@ -0,0 +1,614 @@
+// Package markdown converts CommonMark to HTML for email bodies.
+//
+// It implements the CommonMark block and inline structure used in practice:
+// headings, paragraphs, block quotes, lists, code blocks, thematic breaks,
+// emphasis, code spans, links, images, autolinks, reference links and hard
+// line breaks. Raw HTML is escaped rather than passed through, and links
+// with schemes other than http, https, mailto and tel are dropped, so the
+// output is safe to send.
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	thematicBreakBlock
+	codeBlock
+	quoteBlock
+	listBlock
+)
+
+type block struct {
+	kind blockKind
+
+	// text is the raw inline text of paragraphs and headings, or the content
+	// of code blocks.
+	text  string
+	level int    // heading level
+	info  string // code block info string
+
+	children []*block   // block quote content
+	items    [][]*block // list items
+	ordered  bool
+	start    int
+	tight    bool
+
+	// blankBefore records a blank line before the block, for list tightness.
+	blankBefore bool
+}
+
+type linkRef struct {
+	dest  string
+	title string
+}
+
+type parser struct {
+	refs map[string]linkRef
+}
+
+// ToHTML converts CommonMark source to HTML.
+func ToHTML(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+
+	p := &parser{refs: make(map[string]linkRef)}
+	blocks := p.parseBlocks(lines)
+
+	var b strings.Builder
+	p.renderBlocks(&b, blocks, false)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (p *parser) parseBlocks(lines []string) []*block {
+	var blocks []*block
+	blank := false
+	add := func(b *block) {
+		b.blankBefore = blank && len(blocks) > 0
+		blank = false
+		blocks = append(blocks, b)
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			blank = true
+			i++
+			continue
+		}
+
+		indent := indentOf(line)
+		if indent >= 4 {
+			var code []string
+			for i < len(lines) && (isBlank(lines[i]) || indentOf(lines[i]) >= 4) {
+				code = append(code, stripIndent(lines[i], 4))
+				i++
+			}
+			for len(code) > 0 && isBlank(code[len(code)-1]) {
+				code = code[:len(code)-1]
+			}
+			add(&block{kind: codeBlock, text: strings.Join(code, "\n") + "\n"})
+			continue
+		}
+
+		rest := line[indent:]
+
+		if fence, n, info, ok := fenceStart(rest); ok {
+			var code []string
+			i++
+			for i < len(lines) {
+				l := lines[i]
+				i++
+				if isFenceEnd(l, fence, n) {
+					break
+				}
+				code = append(code, stripIndent(l, indent))
+			}
+			text := strings.Join(code, "\n")
+			if len(code) > 0 {
+				text += "\n"
+			}
+			add(&block{kind: codeBlock, text: text, info: info})
+			continue
+		}
+
+		if level, text, ok := atxHeading(rest); ok {
+			add(&block{kind: headingBlock, level: level, text: text})
+			i++
+			continue
+		}
+
+		if isThematicBreak(rest) {
+			add(&block{kind: thematicBreakBlock})
+			i++
+			continue
+		}
+
+		if strings.HasPrefix(rest, ">") {
+			var inner []string
+			for i < len(lines) {
+				l := lines[i]
+				ind := indentOf(l)
+				if ind < 4 && strings.HasPrefix(l[ind:], ">") {
+					inner = append(inner, strings.TrimPrefix(l[ind+1:], " "))
+					i++
+					continue
+				}
+				if !isBlank(l) && len(inner) > 0 && !isBlank(inner[len(inner)-1]) && !startsBlock(l) {
+					inner = append(inner, l)
+					i++
+					continue
+				}
+				break
+			}
+			add(&block{kind: quoteBlock, children: p.parseBlocks(inner)})
+			continue
+		}
+
+		if m, ok := listMarkerAt(line); ok {
+			var list *block
+			list, i = p.parseList(lines, i, m)
+			add(list)
+			continue
+		}
+
+		// Paragraph, possibly a setext heading
+		para := []string{strings.TrimLeft(line, " ")}
+		level := 0
+		for i++; i < len(lines); i++ {
+			l := lines[i]
+			if isBlank(l) {
+				break
+			}
+			if ind := indentOf(l); ind < 4 {
+				if lvl := setextLevel(l[ind:]); lvl > 0 {
+					level = lvl
+					i++
+					break
+				}
+			}
+			if startsBlock(l) {
+				break
+			}
+			para = append(para, strings.TrimLeft(l, " "))
+		}
+
+		text := p.parseRefDefs(strings.Join(para, "\n"))
+		text = strings.TrimRight(text, " ")
+		if text == "" {
+			continue
+		}
+		if level > 0 {
+			add(&block{kind: headingBlock, level: level, text: text})
+		} else {
+			add(&block{kind: paragraphBlock, text: text})
+		}
+	}
+	return blocks
+}
+
+// parseList parses the list starting at lines[i] and returns it with the
+// index of the first line after it.
+func (p *parser) parseList(lines []string, i int, first listMarker) (*block, int) {
+	list := &block{kind: listBlock, ordered: first.ordered, start: first.start, tight: true}
+
+	for i < len(lines) {
+		m, ok := listMarkerAt(lines[i])
+		if !ok || m.ordered != first.ordered || m.delim != first.delim {
+			break
+		}
+
+		item := []string{lines[i][min(m.width, len(lines[i])):]}
+		for i++; i < len(lines); i++ {
+			l := lines[i]
+			switch {
+			case isBlank(l):
+				item = append(item, "")
+				continue
+			case indentOf(l) >= m.width:
+				item = append(item, l[m.width:])
+				continue
+			case isListItem(l):
+				// The next item of this or another list
+			case !isBlank(item[len(item)-1]) && !startsBlock(l):
+				// Lazy continuation of a paragraph
+				item = append(item, strings.TrimLeft(l, " "))
+				continue
+			}
+			break
+		}
+
+		trailingBlank := false
+		for len(item) > 1 && isBlank(item[len(item)-1]) {
+			item = item[:len(item)-1]
+			trailingBlank = true
+		}
+
+		children := p.parseBlocks(item)
+		for _, child := range children {
+			if child.blankBefore {
+				list.tight = false
+			}
+		}
+		list.items = append(list.items, children)
+
+		if trailingBlank {
+			if next, ok := listMarkerAt(lineAt(lines, i)); ok && next.ordered == first.ordered && next.delim == first.delim {
+				list.tight = false
+			}
+		}
+	}
+	return list, i
+}
+
+// parseRefDefs records the link reference definitions at the start of a
+// paragraph and returns the rest of its text.
+func (p *parser) parseRefDefs(text string) string {
+	for strings.HasPrefix(text, "[") {
+		label, ref, rest, ok := parseRefDef(text)
+		if !ok {
+			break
+		}
+		if _, exists := p.refs[label]; !exists {
+			p.refs[label] = ref
+		}
+		text = rest
+	}
+	return text
+}
+
+// parseRefDef parses a definition such as [label]: /url "title".
+func parseRefDef(s string) (label string, ref linkRef, rest string, ok bool) {
+	end := strings.Index(s, "]:")
+	if end < 0 {
+		return "", ref, s, false
+	}
+	label = normalizeLabel(s[1:end])
+	if label == "" || strings.ContainsAny(s[1:end], "[]") {
+		return "", ref, s, false
+	}
+
+	i := skipSpaceNewline(s, end+2)
+	dest, i, ok := parseDestination(s, i)
+	if !ok {
+		return "", ref, s, false
+	}
+	ref.dest = dest
+
+	// The definition ends after the destination unless a title follows
+	afterDest := i
+	j := skipSpaceNewline(s, i)
+	if j > i && j < len(s) && strings.IndexByte(`"'(`, s[j]) >= 0 {
+		if title, k, ok := parseTitle(s, j); ok && restOfLineBlank(s, k) {
+			ref.title = title
+			return label, ref, nextLine(s, k), true
+		}
+	}
+	if !restOfLineBlank(s, afterDest) {
+		return "", linkRef{}, s, false
+	}
+	return label, ref, nextLine(s, afterDest), true
+}
+
+func (p *parser) renderBlocks(b *strings.Builder, blocks []*block, tight bool) {
+	for _, bl := range blocks {
+		switch bl.kind {
+		case paragraphBlock:
+			if tight {
+				b.WriteString(p.inline(bl.text))
+				b.WriteByte('\n')
+			} else {
+				b.WriteString("<p>" + p.inline(bl.text) + "</p>\n")
+			}
+		case headingBlock:
+			tag := "h" + string(rune('0'+bl.level))
+			b.WriteString("<" + tag + ">" + p.inline(bl.text) + "</" + tag + ">\n")
+		case thematicBreakBlock:
+			b.WriteString("<hr>\n")
+		case codeBlock:
+			b.WriteString("<pre><code")
+			if lang := strings.Fields(bl.info); len(lang) > 0 {
+				b.WriteString(` class="language-` + escapeHTML(unescapeBackslashes(lang[0])) + `"`)
+			}
+			b.WriteString(">" + escapeHTML(bl.text) + "</code></pre>\n")
+		case quoteBlock:
+			b.WriteString("<blockquote>\n")
+			p.renderBlocks(b, bl.children, false)
+			b.WriteString("</blockquote>\n")
+		case listBlock:
+			tag := "ul"
+			if bl.ordered {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag)
+			if bl.ordered && bl.start != 1 {
+				b.WriteString(` start="` + strconv.Itoa(bl.start) + `"`)
+			}
+			b.WriteString(">\n")
+			for _, item := range bl.items {
+				b.WriteString("<li>")
+				var inner strings.Builder
+				p.renderBlocks(&inner, item, bl.tight)
+				content := inner.String()
+				if bl.tight && len(item) > 0 && item[0].kind == paragraphBlock {
+					content = strings.TrimSuffix(content, "\n")
+					if len(item) > 1 {
+						content += "\n"
+					}
+				} else if content != "" {
+					b.WriteByte('\n')
+				}
+				b.WriteString(content)
+				b.WriteString("</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+		}
+	}
+}
+
+type listMarker struct {
+	ordered bool
+	delim   byte // bullet character, or '.' or ')' after a number
+	start   int
+	width   int // column where the item content starts
+	empty   bool
+}
+
+// listMarkerAt returns the list marker that starts line, if any.
+func listMarkerAt(line string) (listMarker, bool) {
+	indent := indentOf(line)
+	if indent >= 4 || indent >= len(line) {
+		return listMarker{}, false
+	}
+	rest := line[indent:]
+	if isThematicBreak(rest) {
+		return listMarker{}, false
+	}
+
+	var m listMarker
+	n := 0
+	switch c := rest[0]; {
+	case c == '-' || c == '+' || c == '*':
+		m.delim = c
+		n = 1
+	case isDigit(c):
+		for n < len(rest) && n < 9 && isDigit(rest[n]) {
+			m.start = m.start*10 + int(rest[n]-'0')
+			n++
+		}
+		if n >= len(rest) || (rest[n] != '.' && rest[n] != ')') {
+			return listMarker{}, false
+		}
+		m.ordered = true
+		m.delim = rest[n]
+		n++
+	default:
+		return listMarker{}, false
+	}
+
+	after := rest[n:]
+	if after != "" && after[0] != ' ' {
+		return listMarker{}, false
+	}
+	spaces := indentOf(after)
+	switch {
+	case isBlank(after):
+		m.empty = true
+		m.width = indent + n + 1
+	case spaces > 4:
+		m.width = indent + n + 1
+	default:
+		m.width = indent + n + spaces
+	}
+	return m, true
+}
+
+func isListItem(line string) bool {
+	_, ok := listMarkerAt(line)
+	return ok
+}
+
+// startsBlock reports whether line starts a block that interrupts a
+// paragraph.
+func startsBlock(line string) bool {
+	indent := indentOf(line)
+	if indent >= 4 {
+		return false
+	}
+	rest := line[indent:]
+	if _, _, _, ok := fenceStart(rest); ok {
+		return true
+	}
+	if _, _, ok := atxHeading(rest); ok {
+		return true
+	}
+	if isThematicBreak(rest) || strings.HasPrefix(rest, ">") {
+		return true
+	}
+	if m, ok := listMarkerAt(line); ok && !m.empty && (!m.ordered || m.start == 1) {
+		return true
+	}
+	return false
+}
+
+func fenceStart(s string) (fence byte, n int, info string, ok bool) {
+	if s == "" || (s[0] != '`' && s[0] != '~') {
+		return 0, 0, "", false
+	}
+	fence = s[0]
+	for n < len(s) && s[n] == fence {
+		n++
+	}
+	if n < 3 {
+		return 0, 0, "", false
+	}
+	info = strings.TrimSpace(s[n:])
+	if fence == '`' && strings.IndexByte(info, '`') >= 0 {
+		return 0, 0, "", false
+	}
+	return fence, n, info, true
+}
+
+func isFenceEnd(line string, fence byte, n int) bool {
+	indent := indentOf(line)
+	if indent >= 4 {
+		return false
+	}
+	s := strings.TrimRight(line[indent:], " ")
+	if len(s) < n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != fence {
+			return false
+		}
+	}
+	return true
+}
+
+func atxHeading(s string) (level int, text string, ok bool) {
+	for level < len(s) && s[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(s) && s[level] != ' ') {
+		return 0, "", false
+	}
+	text = strings.TrimSpace(s[level:])
+
+	// Remove an optional closing sequence of #s
+	trimmed := strings.TrimRight(text, "#")
+	if trimmed == "" {
+		text = ""
+	} else if len(trimmed) < len(text) && trimmed[len(trimmed)-1] == ' ' {
+		text = strings.TrimRight(trimmed, " ")
+	}
+	return level, text, true
+}
+
+func isThematicBreak(s string) bool {
+	s = strings.TrimRight(s, " ")
+	if s == "" || (s[0] != '-' && s[0] != '*' && s[0] != '_') {
+		return false
+	}
+	count := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case s[0]:
+			count++
+		case ' ':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+// setextLevel returns 1 or 2 if s is a setext heading underline, or 0.
+func setextLevel(s string) int {
+	s = strings.TrimRight(s, " ")
+	if s == "" {
+		return 0
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != s[0] {
+			return 0
+		}
+	}
+	switch s[0] {
+	case '=':
+		return 1
+	case '-':
+		return 2
+	}
+	return 0
+}
+
+func normalizeLabel(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func skipSpaceNewline(s string, i int) int {
+	newline := false
+	for i < len(s) && (s[i] == ' ' || (s[i] == '\n' && !newline)) {
+		if s[i] == '\n' {
+			newline = true
+		}
+		i++
+	}
+	return i
+}
+
+func restOfLineBlank(s string, i int) bool {
+	end := strings.IndexByte(s[i:], '\n')
+	if end < 0 {
+		end = len(s) - i
+	}
+	return isBlank(s[i : i+end])
+}
+
+func nextLine(s string, i int) string {
+	if end := strings.IndexByte(s[i:], '\n'); end >= 0 {
+		return s[i+end+1:]
+	}
+	return ""
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+func isBlank(s string) bool {
+	return strings.TrimLeft(s, " ") == ""
+}
+
+func indentOf(s string) int {
+	n := 0
+	for n < len(s) && s[n] == ' ' {
+		n++
+	}
+	return n
+}
+
+// stripIndent removes up to n leading spaces from s.
+func stripIndent(s string, n int) string {
+	i := 0
+	for i < n && i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return s[i:]
+}
+
+// expandTabs replaces tabs with spaces up to the next multiple of four
+// columns.
+func expandTabs(s string) string {
+	if strings.IndexByte(s, '\t') < 0 {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			n := 4 - col%4
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
@@ -0,0 +1,48 @@
+package markdown
+
+import "testing"
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"paragraphs", "Hello\nworld\n\nSecond", "<p>Hello\nworld</p>\n<p>Second</p>"},
+		{"atx headings", "# One\n### Three ###\n####### no", "<h1>One</h1>\n<h3>Three</h3>\n<p>####### no</p>"},
+		{"setext headings", "Title\n=====\n\nSub\n---", "<h1>Title</h1>\n<h2>Sub</h2>"},
+		{"thematic break", "a\n\n* * *\n\nb", "<p>a</p>\n<hr>\n<p>b</p>"},
+		{"emphasis", "*em* **strong** ***both*** _u_ snake_case_name", "<p><em>em</em> <strong>strong</strong> <em><strong>both</strong></em> <em>u</em> snake_case_name</p>"},
+		{"nested emphasis", "**bold *and em***", "<p><strong>bold <em>and em</em></strong></p>"},
+		{"unmatched emphasis", "2 * 3 * 4 and **open", "<p>2 * 3 * 4 and **open</p>"},
+		{"code span", "use `a < b` or `` x`y ``", "<p>use <code>a &lt; b</code> or <code>x`y</code></p>"},
+		{"escapes and entities", `\*not em\* &copy; & <b>`, "<p>*not em* © &amp; &lt;b&gt;</p>"},
+		{"hard breaks", "one  \ntwo\\\nthree", "<p>one<br>\ntwo<br>\nthree</p>"},
+		{"inline link", `[site](https://example.com "Home") and [rel](/path)`, `<p><a href="https://example.com" title="Home">site</a> and <a href="/path">rel</a></p>`},
+		{"link with emphasis", "[**bold** link](https://example.com)", `<p><a href="https://example.com"><strong>bold</strong> link</a></p>`},
+		{"reference links", "[docs][d] and [d] and [D][]\n\n[d]: https://example.com/docs 'Docs'", `<p><a href="https://example.com/docs" title="Docs">docs</a> and <a href="https://example.com/docs" title="Docs">d</a> and <a href="https://example.com/docs" title="Docs">D</a></p>`},
+		{"missing reference", "[nope] [a](", "<p>[nope] [a](</p>"},
+		{"image", `![a *logo*](https://example.com/logo.png)`, `<p><img src="https://example.com/logo.png" alt="a logo"></p>`},
+		{"autolinks", "<https://example.com> <jane@example.com>", `<p><a href="https://example.com">https://example.com</a> <a href="mailto:jane@example.com">jane@example.com</a></p>`},
+		{"unsafe links", "[x](javascript:alert(1)) <javascript:alert(1)>", `<p><a>x</a> javascript:alert(1)</p>`},
+		{"raw html escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"fenced code", "```go\nfmt.Println(\"<hi>\")\n```", "<pre><code class=\"language-go\">fmt.Println(&quot;&lt;hi&gt;&quot;)\n</code></pre>"},
+		{"indented code", "    a\n    b\n\nc", "<pre><code>a\nb\n</code></pre>\n<p>c</p>"},
+		{"blockquote", "> quoted\nlazy\n>\n> - item", "<blockquote>\n<p>quoted\nlazy</p>\n<ul>\n<li>item</li>\n</ul>\n</blockquote>"},
+		{"tight list", "- one\n- two\n\npara", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n<p>para</p>"},
+		{"loose list", "1. one\n\n2. two", "<ol>\n<li>\n<p>one</p>\n</li>\n<li>\n<p>two</p>\n</li>\n</ol>"},
+		{"ordered start", "3) three\n4) four", "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>"},
+		{"nested list", "- a\n  - b\n  - c\n- d", "<ul>\n<li>a\n<ul>\n<li>b</li>\n<li>c</li>\n</ul>\n</li>\n<li>d</li>\n</ul>"},
+		{"list changes marker", "- a\n+ b", "<ul>\n<li>a</li>\n</ul>\n<ul>\n<li>b</li>\n</ul>"},
+		{"list interrupts paragraph", "text\n- item", "<p>text</p>\n<ul>\n<li>item</li>\n</ul>"},
+		{"number does not interrupt paragraph", "the year\n2024. was good", "<p>the year\n2024. was good</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.in); got != tt.want {
+				t.Errorf("expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
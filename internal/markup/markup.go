@@ -0,0 +1,431 @@
+// Package markup is a small, forgiving HTML parser for email bodies. It
+// builds a tree that can be inspected, modified and rendered back to HTML,
+// and converts HTML to plain text.
+//
+// It is not a conforming HTML5 parser: it handles the well-formed and mostly
+// well-formed markup found in email templates without the standard's error
+// recovery rules.
+package markup
+
+import (
+	"html"
+	"strings"
+)
+
+// NodeType is the type of a Node.
+type NodeType int
+
+const (
+	DocumentNode NodeType = iota
+	ElementNode
+	TextNode
+	CommentNode
+	DoctypeNode
+)
+
+// Attr is an element attribute. Val is unescaped.
+type Attr struct {
+	Key string
+	Val string
+}
+
+// Node is a node of a parsed document.
+//
+// Data is the lowercase tag name of elements, the raw (still escaped) text of
+// text nodes, and the content of comments and doctypes.
+type Node struct {
+	Type     NodeType
+	Data     string
+	Attr     []Attr
+	Parent   *Node
+	Children []*Node
+}
+
+// GetAttr returns the value of the attribute key and whether it is present.
+func (n *Node) GetAttr(key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// SetAttr sets the attribute key, adding it if it is not present.
+func (n *Node) SetAttr(key, val string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, Attr{Key: key, Val: val})
+}
+
+// AppendChild adds child as the last child of n.
+func (n *Node) AppendChild(child *Node) {
+	child.Parent = n
+	n.Children = append(n.Children, child)
+}
+
+// InsertBefore adds child to the children of n just before ref, or as the
+// last child if ref is nil or not a child of n.
+func (n *Node) InsertBefore(child, ref *Node) {
+	for i, c := range n.Children {
+		if c == ref {
+			child.Parent = n
+			n.Children = append(n.Children[:i], append([]*Node{child}, n.Children[i:]...)...)
+			return
+		}
+	}
+	n.AppendChild(child)
+}
+
+// RemoveChild removes child from the children of n.
+func (n *Node) RemoveChild(child *Node) {
+	for i, c := range n.Children {
+		if c == child {
+			n.Children = append(n.Children[:i], n.Children[i+1:]...)
+			child.Parent = nil
+			return
+		}
+	}
+}
+
+// Find returns the first element named tag in the subtree of n, or nil.
+func (n *Node) Find(tag string) *Node {
+	var found *Node
+	n.Walk(func(node *Node) bool {
+		if found == nil && node.Type == ElementNode && node.Data == tag {
+			found = node
+		}
+		return found == nil
+	})
+	return found
+}
+
+// Walk calls fn for n and its descendants in document order. Children of a
+// node are skipped when fn returns false.
+func (n *Node) Walk(fn func(*Node) bool) {
+	if !fn(n) {
+		return
+	}
+	// Copy so fn may modify the children being walked
+	children := append([]*Node(nil), n.Children...)
+	for _, child := range children {
+		child.Walk(fn)
+	}
+}
+
+// Text returns the unescaped text content of n.
+func (n *Node) Text() string {
+	var b strings.Builder
+	n.Walk(func(node *Node) bool {
+		if node.Type == TextNode {
+			b.WriteString(html.UnescapeString(node.Data))
+		}
+		return true
+	})
+	return b.String()
+}
+
+// voidElements have no content or end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements contain text that is not parsed as markup.
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "textarea": true, "title": true,
+}
+
+// IsVoid reports whether tag is a void element such as br or img.
+func IsVoid(tag string) bool {
+	return voidElements[tag]
+}
+
+// Parse parses an HTML document or fragment. It never fails; malformed
+// markup is recovered from as text or by closing open elements.
+func Parse(s string) *Node {
+	doc := &Node{Type: DocumentNode}
+	stack := []*Node{doc}
+	current := func() *Node { return stack[len(stack)-1] }
+
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+		parent := current()
+		if last := len(parent.Children) - 1; last >= 0 && parent.Children[last].Type == TextNode {
+			parent.Children[last].Data += text
+			return
+		}
+		parent.AppendChild(&Node{Type: TextNode, Data: text})
+	}
+
+	closeTag := func(tag string) {
+		for i := len(stack) - 1; i > 0; i-- {
+			if stack[i].Data == tag {
+				stack = stack[:i]
+				return
+			}
+		}
+	}
+
+	for i := 0; i < len(s); {
+		lt := strings.IndexByte(s[i:], '<')
+		if lt < 0 {
+			appendText(s[i:])
+			break
+		}
+		appendText(s[i : i+lt])
+		i += lt
+
+		switch {
+		case strings.HasPrefix(s[i:], "<!--"):
+			end := strings.Index(s[i+4:], "-->")
+			if end < 0 {
+				current().AppendChild(&Node{Type: CommentNode, Data: s[i+4:]})
+				i = len(s)
+				continue
+			}
+			current().AppendChild(&Node{Type: CommentNode, Data: s[i+4 : i+4+end]})
+			i += 4 + end + 3
+
+		case strings.HasPrefix(s[i:], "<!") || strings.HasPrefix(s[i:], "<?"):
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				end = len(s) - i
+			}
+			data := strings.TrimSpace(s[i+2 : i+end])
+			if strings.HasPrefix(s[i:], "<!") && len(data) >= 7 && strings.EqualFold(data[:7], "doctype") {
+				current().AppendChild(&Node{Type: DoctypeNode, Data: strings.TrimSpace(data[7:])})
+			}
+			i += end + 1
+
+		case strings.HasPrefix(s[i:], "</"):
+			name, _ := scanTagName(s[i+2:])
+			if name == "" {
+				appendText(s[i : i+2])
+				i += 2
+				continue
+			}
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				end = len(s) - i
+			}
+			closeTag(name)
+			i += end + 1
+
+		default:
+			name, n := scanTagName(s[i+1:])
+			if name == "" {
+				appendText("<")
+				i++
+				continue
+			}
+			attrs, selfClosing, consumed := scanAttrs(s[i+1+n:])
+			i += 1 + n + consumed
+
+			implicitlyClose(&stack, name)
+			el := &Node{Type: ElementNode, Data: name, Attr: attrs}
+			current().AppendChild(el)
+
+			if voidElements[name] || selfClosing {
+				continue
+			}
+			if rawTextElements[name] {
+				end := indexFold(s[i:], "</"+name)
+				if end < 0 {
+					end = len(s) - i
+				}
+				if end > 0 {
+					el.AppendChild(&Node{Type: TextNode, Data: s[i : i+end]})
+				}
+				i += end
+				if i < len(s) {
+					if gt := strings.IndexByte(s[i:], '>'); gt >= 0 {
+						i += gt + 1
+					} else {
+						i = len(s)
+					}
+				}
+				continue
+			}
+			stack = append(stack, el)
+		}
+	}
+	return doc
+}
+
+// implicitlyClose closes elements that cannot contain an element named tag,
+// such as an open p before a div, or an open li before another li.
+func implicitlyClose(stack *[]*Node, tag string) {
+	closes := func(open string) bool {
+		switch open {
+		case "p":
+			return blockElements[tag]
+		case "li":
+			return tag == "li"
+		case "td", "th":
+			return tag == "td" || tag == "th" || tag == "tr"
+		case "tr":
+			return tag == "tr"
+		case "option":
+			return tag == "option"
+		}
+		return false
+	}
+
+	s := *stack
+	for len(s) > 1 && closes(s[len(s)-1].Data) {
+		s = s[:len(s)-1]
+	}
+	*stack = s
+}
+
+// scanTagName returns the lowercase tag name at the start of s and its length.
+func scanTagName(s string) (string, int) {
+	n := 0
+	for n < len(s) {
+		c := s[n]
+		if isLetter(c) || (n > 0 && (isDigit(c) || c == '-' || c == ':')) {
+			n++
+			continue
+		}
+		break
+	}
+	return strings.ToLower(s[:n]), n
+}
+
+// scanAttrs parses attributes up to and including the closing '>'. It returns
+// the attributes, whether the tag ends with "/>", and the bytes consumed.
+func scanAttrs(s string) ([]Attr, bool, int) {
+	var attrs []Attr
+	i := 0
+	for i < len(s) {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return attrs, false, i + 1
+		}
+		if s[i] == '/' {
+			if i+1 < len(s) && s[i+1] == '>' {
+				return attrs, true, i + 2
+			}
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && !(s[i] == '/' && i+1 < len(s) && s[i+1] == '>') {
+			i++
+		}
+		key := strings.ToLower(s[start:i])
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+
+		val := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					val = s[i+1:]
+					i = len(s)
+				} else {
+					val = s[i+1 : i+1+end]
+					i += end + 2
+				}
+			} else {
+				start := i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				val = s[start:i]
+			}
+		}
+		if key != "" {
+			attrs = append(attrs, Attr{Key: key, Val: html.UnescapeString(val)})
+		}
+	}
+	return attrs, false, len(s)
+}
+
+// Render returns the HTML of n and its descendants.
+func Render(n *Node) string {
+	var b strings.Builder
+	render(&b, n)
+	return b.String()
+}
+
+func render(b *strings.Builder, n *Node) {
+	switch n.Type {
+	case DocumentNode:
+		for _, child := range n.Children {
+			render(b, child)
+		}
+	case TextNode:
+		b.WriteString(n.Data)
+	case CommentNode:
+		b.WriteString("<!--")
+		b.WriteString(n.Data)
+		b.WriteString("-->")
+	case DoctypeNode:
+		b.WriteString("<!DOCTYPE ")
+		b.WriteString(n.Data)
+		b.WriteString(">")
+	case ElementNode:
+		b.WriteByte('<')
+		b.WriteString(n.Data)
+		for _, attr := range n.Attr {
+			b.WriteByte(' ')
+			b.WriteString(attr.Key)
+			b.WriteString(`="`)
+			b.WriteString(EscapeAttr(attr.Val))
+			b.WriteByte('"')
+		}
+		b.WriteByte('>')
+		if voidElements[n.Data] {
+			return
+		}
+		for _, child := range n.Children {
+			render(b, child)
+		}
+		b.WriteString("</")
+		b.WriteString(n.Data)
+		b.WriteByte('>')
+	}
+}
+
+var attrEscaper = strings.NewReplacer(`&`, "&amp;", `"`, "&quot;", `<`, "&lt;", `>`, "&gt;")
+
+// EscapeAttr escapes s for use in a double-quoted attribute value.
+func EscapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+func indexFold(s, substr string) int {
+	n := len(substr)
+	for i := 0; i+n <= len(s); i++ {
+		if strings.EqualFold(s[i:i+n], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isSpace(c byte) bool  { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
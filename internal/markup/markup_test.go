@@ -0,0 +1,63 @@
+package markup
+
+import "testing"
+
+func TestParseRender(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"simple", `<p class="a">Hi <b>there</b></p>`, `<p class="a">Hi <b>there</b></p>`},
+		{"void elements", `<p>a<br>b<img src="x.png" alt=""></p>`, `<p>a<br>b<img src="x.png" alt=""></p>`},
+		{"self closing", `<div/><span>x</span>`, `<div></div><span>x</span>`},
+		{"uppercase tags", `<DIV Class=x>y</DIV>`, `<div class="x">y</div>`},
+		{"attribute escaping", `<a title='say "hi" &amp; bye'>x</a>`, `<a title="say &quot;hi&quot; &amp; bye">x</a>`},
+		{"text entities preserved", `<p>a &amp; b &lt; c</p>`, `<p>a &amp; b &lt; c</p>`},
+		{"comments and doctype", `<!DOCTYPE html><!-- note --><p>x</p>`, `<!DOCTYPE html><!-- note --><p>x</p>`},
+		{"raw text", `<style>p > a { color: red }</style>`, `<style>p > a { color: red }</style>`},
+		{"unclosed elements", `<div><p>one<p>two</div>`, `<div><p>one</p><p>two</p></div>`},
+		{"list items", `<ul><li>a<li>b</ul>`, `<ul><li>a</li><li>b</li></ul>`},
+		{"table cells", `<table><tr><td>a<td>b<tr><td>c</table>`, `<table><tr><td>a</td><td>b</td></tr><tr><td>c</td></tr></table>`},
+		{"stray end tag", `<p>a</span>b</p>`, `<p>ab</p>`},
+		{"stray less than", `<p>1 < 2</p>`, `<p>1 < 2</p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(Parse(tt.in)); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNodeHelpers(t *testing.T) {
+	doc := Parse(`<html><head></head><body><p id="x">Tom &amp; Jerry</p></body></html>`)
+
+	p := doc.Find("p")
+	if p == nil {
+		t.Fatal("expected to find p")
+	}
+	if id, ok := p.GetAttr("id"); !ok || id != "x" {
+		t.Errorf("expected id x, got %q", id)
+	}
+	if p.Text() != "Tom & Jerry" {
+		t.Errorf("expected text 'Tom & Jerry', got %q", p.Text())
+	}
+
+	p.SetAttr("style", "color:red")
+	p.SetAttr("id", "y")
+	head := doc.Find("head")
+	head.AppendChild(&Node{Type: ElementNode, Data: "meta"})
+	head.InsertBefore(&Node{Type: ElementNode, Data: "title"}, head.Children[0])
+	doc.Find("body").RemoveChild(p)
+
+	want := `<html><head><title></title><meta></head><body></body></html>`
+	if got := Render(doc); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if v, _ := p.GetAttr("id"); v != "y" || len(p.Attr) != 2 {
+		t.Errorf("unexpected attributes %v", p.Attr)
+	}
+}
@@ -0,0 +1,215 @@
+package markup
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// blockElements start on a new line in plain text and close an open p.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"center": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "li": true, "main": true,
+	"nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "tbody": true, "tfoot": true, "thead": true, "tr": true,
+	"ul": true,
+}
+
+// paragraphElements are separated from surrounding text by a blank line.
+var paragraphElements = map[string]bool{
+	"blockquote": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "ol": true, "p": true, "pre": true, "table": true,
+	"ul": true,
+}
+
+// hiddenElements have no readable text.
+var hiddenElements = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true, "title": true,
+}
+
+// ToText converts HTML to readable plain text. Paragraphs and headings are
+// separated by blank lines, list items are prefixed with "- " or their number,
+// links are written as "text (url)", and images are replaced by their alt text.
+func ToText(s string) string {
+	w := &textWriter{}
+	w.node(Parse(s))
+
+	lines := strings.Split(w.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+type textWriter struct {
+	b   strings.Builder
+	pre int
+
+	// newlines is the number of newlines the output ends with.
+	newlines int
+
+	// space is true when the output ends with a space.
+	space bool
+}
+
+// ensureNewlines ends the output with at least n newlines, except at the start.
+func (w *textWriter) ensureNewlines(n int) {
+	if w.b.Len() == 0 {
+		return
+	}
+	for w.newlines < n {
+		w.b.WriteByte('\n')
+		w.newlines++
+		w.space = false
+	}
+}
+
+func (w *textWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	w.b.WriteString(s)
+	w.space = s[len(s)-1] == ' '
+	trimmed := strings.TrimRight(s, "\n")
+	if trimmed == "" {
+		w.newlines += len(s)
+	} else {
+		w.newlines = len(s) - len(trimmed)
+	}
+}
+
+// text writes a text node, collapsing whitespace outside pre elements.
+func (w *textWriter) text(raw string) {
+	s := html.UnescapeString(raw)
+	if w.pre > 0 {
+		w.write(s)
+		return
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s != "" && w.b.Len() > 0 && w.newlines == 0 && !w.space {
+			w.write(" ")
+		}
+		return
+	}
+	out := strings.Join(fields, " ")
+	if isSpace(s[0]) && w.b.Len() > 0 && w.newlines == 0 && !w.space {
+		out = " " + out
+	}
+	if isSpace(s[len(s)-1]) {
+		out += " "
+	}
+	w.write(out)
+}
+
+func (w *textWriter) children(n *Node) {
+	for _, child := range n.Children {
+		w.node(child)
+	}
+}
+
+func (w *textWriter) node(n *Node) {
+	switch n.Type {
+	case DocumentNode:
+		w.children(n)
+		return
+	case TextNode:
+		w.text(n.Data)
+		return
+	case ElementNode:
+	default:
+		return
+	}
+
+	tag := n.Data
+	if hiddenElements[tag] {
+		return
+	}
+
+	switch {
+	case paragraphElements[tag]:
+		w.ensureNewlines(2)
+	case blockElements[tag]:
+		w.ensureNewlines(1)
+	}
+
+	switch tag {
+	case "br":
+		w.write("\n")
+	case "hr":
+		w.write("--------")
+		w.ensureNewlines(2)
+	case "img":
+		if alt, _ := n.GetAttr("alt"); strings.TrimSpace(alt) != "" {
+			w.text(alt)
+		}
+	case "a":
+		w.link(n)
+	case "li":
+		w.listItem(n)
+	case "td", "th":
+		w.children(n)
+		w.write(" ")
+	case "pre":
+		w.pre++
+		w.children(n)
+		w.pre--
+	default:
+		w.children(n)
+	}
+
+	switch {
+	case paragraphElements[tag]:
+		w.ensureNewlines(2)
+	case blockElements[tag]:
+		w.ensureNewlines(1)
+	}
+}
+
+// link writes the link text followed by the URL, unless the text is the URL.
+func (w *textWriter) link(n *Node) {
+	start := w.b.Len()
+	w.children(n)
+	text := strings.TrimSpace(w.b.String()[start:])
+
+	href, _ := n.GetAttr("href")
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return
+	}
+	url := strings.TrimPrefix(href, "mailto:")
+	if text == "" {
+		w.write(url)
+		return
+	}
+	if text == url || text == href {
+		return
+	}
+	if !w.space {
+		w.write(" ")
+	}
+	w.write("(" + url + ")")
+}
+
+// listItem writes a list item prefixed by its number in ordered lists or "- ".
+func (w *textWriter) listItem(n *Node) {
+	prefix := "- "
+	if parent := n.Parent; parent != nil && parent.Type == ElementNode && parent.Data == "ol" {
+		index := 1
+		for _, sibling := range parent.Children {
+			if sibling == n {
+				break
+			}
+			if sibling.Type == ElementNode && sibling.Data == "li" {
+				index++
+			}
+		}
+		prefix = strconv.Itoa(index) + ". "
+	}
+	w.write(prefix)
+	w.children(n)
+}
@@ -0,0 +1,70 @@
+package markup
+
+import "testing"
+
+func TestToText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			in:   "<p>Hello\n   world</p><p>Second</p>",
+			want: "Hello world\n\nSecond",
+		},
+		{
+			name: "document with head",
+			in:   `<html><head><title>T</title><style>p{}</style></head><body><h1>Welcome</h1><div>One</div><div>Two</div></body></html>`,
+			want: "Welcome\n\nOne\nTwo",
+		},
+		{
+			name: "links",
+			in:   `<p>Visit <a href="https://example.com">our site</a> or <a href="https://example.com">https://example.com</a> or <a href="mailto:hi@example.com">email us</a>.</p>`,
+			want: "Visit our site (https://example.com) or https://example.com or email us (hi@example.com).",
+		},
+		{
+			name: "anchor link without url",
+			in:   `<a href="#top">Top</a>`,
+			want: "Top",
+		},
+		{
+			name: "lists",
+			in:   `<ul><li>Apples</li><li>Pears</li></ul><ol><li>First</li><li>Second</li></ol>`,
+			want: "- Apples\n- Pears\n\n1. First\n2. Second",
+		},
+		{
+			name: "line breaks and entities",
+			in:   `Tom &amp; Jerry<br>Line&nbsp;two`,
+			want: "Tom & Jerry\nLine two",
+		},
+		{
+			name: "images",
+			in:   `<img src="logo.png" alt="Acme"> <img src="spacer.gif" alt="">Inc`,
+			want: "Acme Inc",
+		},
+		{
+			name: "tables",
+			in:   `<table><tr><td>Item</td><td>$5</td></tr><tr><td>Tax</td><td>$1</td></tr></table>`,
+			want: "Item $5\nTax $1",
+		},
+		{
+			name: "preformatted",
+			in:   "<pre>a\n  b</pre><p>c</p>",
+			want: "a\n  b\n\nc",
+		},
+		{
+			name: "inline spacing",
+			in:   `<p><b>Bold</b> <i>italic</i></p>`,
+			want: "Bold italic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToText(tt.in); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -70,6 +70,27 @@ func (r *ListsResource) List(ctx context.Context, params *ListListsParams) (*Lis
 	return &result, nil
 }
 
+// eachList calls fn for every contact list, fetching one page at a time.
+func (r *ListsResource) eachList(ctx context.Context, fn func(*List) error) error {
+	params := ListListsParams{Limit: defaultPageSize}
+	for params.Page = 1; ; params.Page++ {
+		result, err := r.List(ctx, &params)
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Data {
+			if err := fn(&result.Data[i]); err != nil {
+				return err
+			}
+		}
+
+		if !result.Pagination.HasNext || len(result.Data) == 0 {
+			return nil
+		}
+	}
+}
+
 // Get retrieves a contact list by ID.
 func (r *ListsResource) Get(ctx context.Context, listID string) (*List, error) {
 	var list List
@@ -79,10 +100,10 @@ func (r *ListsResource) Get(ctx context.Context, listID string) (*List, error) {
 	return &list, nil
 }
 
-// Update updates a contact list.
+// Update partially updates a contact list. Fields not set in params are unchanged.
 func (r *ListsResource) Update(ctx context.Context, listID string, params *UpdateListParams) (*List, error) {
 	var list List
-	if err := r.client.Put(ctx, fmt.Sprintf("/api/v1/contact-lists/%s", listID), params, &list); err != nil {
+	if err := r.client.Patch(ctx, fmt.Sprintf("/api/v1/contact-lists/%s", listID), params, &list); err != nil {
 		return nil, err
 	}
 	return &list, nil
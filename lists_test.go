@@ -174,8 +174,8 @@ func TestListsGet(t *testing.T) {
 
 func TestListsUpdate(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPut {
-			t.Errorf("expected PUT, got %s", r.Method)
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
 		}
 		if r.URL.Path != "/api/v1/contact-lists/list_123" {
 			t.Errorf("expected /api/v1/contact-lists/list_123, got %s", r.URL.Path)
@@ -196,7 +196,7 @@ func TestListsUpdate(t *testing.T) {
 	client := NewClient("sk_test_123", WithBaseURL(server.URL))
 
 	list, err := client.Lists.Update(context.Background(), "list_123", &UpdateListParams{
-		Name: "Updated List",
+		Name: Value("Updated List"),
 	})
 
 	if err != nil {
@@ -47,6 +47,15 @@ type Client struct {
 	// Verification provides access to email verification operations.
 	Verification *VerificationResource
 
+	// CustomFields provides access to contact custom field definitions.
+	CustomFields *CustomFieldsResource
+
+	// Privacy provides access to data-subject export and erasure.
+	Privacy *PrivacyResource
+
+	// Templates provides access to email template operations.
+	Templates *TemplatesResource
+
 	httpClient *HTTPClient
 }
 
@@ -54,10 +63,16 @@ type Client struct {
 type ClientOption func(*clientConfig)
 
 type clientConfig struct {
-	baseURL    string
-	timeout    time.Duration
-	maxRetries int
-	httpClient *http.Client
+	baseURL              string
+	timeout              time.Duration
+	maxRetries           int
+	httpClient           *http.Client
+	verificationCache    VerificationCache
+	verificationCacheTTL map[VerificationStatus]time.Duration
+	validateTemplates    bool
+	templateSchemaTTL    time.Duration
+	bodyTransformers     []BodyTransformer
+	markdown             *MarkdownOptions
 }
 
 // WithBaseURL sets a custom base URL.
@@ -88,12 +103,59 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithVerificationCache enables caching of verification results. Verify and
+// Batch consult the cache before calling the API. Only detailed results are
+// cached; batches returning grouped results are not.
+func WithVerificationCache(cache VerificationCache) ClientOption {
+	return func(c *clientConfig) {
+		c.verificationCache = cache
+	}
+}
+
+// WithVerificationCacheTTL sets how long results with the given status are
+// cached. A TTL of zero disables caching for that status. The defaults are
+// 24h for valid and risky, 7 days for invalid and 1h for unknown results.
+func WithVerificationCacheTTL(status VerificationStatus, ttl time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.verificationCacheTTL[status] = ttl
+	}
+}
+
+// WithTemplateValidation checks the Variables of template sends against the
+// variables the template declares before sending. Declared variables are
+// fetched once per template and cached for ttl, or DefaultTemplateSchemaTTL
+// if ttl is zero.
+func WithTemplateValidation(ttl time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.validateTemplates = true
+		c.templateSchemaTTL = ttl
+	}
+}
+
+// WithBodyTransformer adds a transformer that Emails.Send applies to a copy
+// of the params before sending, such as InlineCSSBody. Transformers run in
+// the order they are added.
+func WithBodyTransformer(t BodyTransformer) ClientOption {
+	return func(c *clientConfig) {
+		c.bodyTransformers = append(c.bodyTransformers, t)
+	}
+}
+
+// WithMarkdownOptions sets the layout and link style used to convert the
+// Markdown of SendEmailParams to HTML.
+func WithMarkdownOptions(opts MarkdownOptions) ClientOption {
+	return func(c *clientConfig) {
+		c.markdown = &opts
+	}
+}
+
 // NewClient creates a new MailBreeze API client.
 func NewClient(apiKey string, opts ...ClientOption) *Client {
 	cfg := &clientConfig{
-		baseURL:    DefaultBaseURL,
-		timeout:    DefaultTimeout,
-		maxRetries: DefaultMaxRetries,
+		baseURL:              DefaultBaseURL,
+		timeout:              DefaultTimeout,
+		maxRetries:           DefaultMaxRetries,
+		verificationCacheTTL: defaultVerificationCacheTTLs(),
 	}
 
 	for _, opt := range opts {
@@ -113,10 +175,24 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 	}
 
 	// Initialize resources
-	client.Emails = &EmailsResource{client: httpClient}
 	client.Lists = &ListsResource{client: httpClient}
 	client.Attachments = &AttachmentsResource{client: httpClient}
-	client.Verification = &VerificationResource{client: httpClient}
+	client.CustomFields = &CustomFieldsResource{client: httpClient}
+	client.Privacy = &PrivacyResource{client: httpClient}
+	client.Templates = &TemplatesResource{client: httpClient}
+	client.Emails = &EmailsResource{
+		client:       httpClient,
+		markdown:     cfg.markdown,
+		transformers: cfg.bodyTransformers,
+	}
+	if cfg.validateTemplates {
+		client.Emails.templateSchemas = newTemplateSchemaCache(client.Templates, cfg.templateSchemaTTL)
+	}
+	client.Verification = &VerificationResource{
+		client:   httpClient,
+		cache:    cfg.verificationCache,
+		cacheTTL: cfg.verificationCacheTTL,
+	}
 
 	return client
 }
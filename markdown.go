@@ -0,0 +1,103 @@
+package mailbreeze
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/MailBreeze/mailbreeze-go/internal/markdown"
+	"github.com/MailBreeze/mailbreeze-go/internal/markup"
+)
+
+// DefaultMarkdownLinkStyle is the style of links in Markdown bodies when
+// MarkdownOptions.LinkStyle is empty.
+const DefaultMarkdownLinkStyle = "color: #2563eb; text-decoration: underline;"
+
+// DefaultMarkdownLayout is the layout of Markdown bodies when
+// MarkdownOptions.Layout is nil: a centered, 600px wide white card.
+var DefaultMarkdownLayout = template.Must(template.New("markdown").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Subject}}</title>
+</head>
+<body style="margin: 0; padding: 0; background-color: #f4f4f5;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0">
+<tr><td align="center" style="padding: 24px;">
+<table role="presentation" width="600" cellpadding="0" cellspacing="0" border="0" style="max-width: 600px; width: 100%; background-color: #ffffff;">
+<tr><td style="padding: 32px; font-family: -apple-system, 'Segoe UI', Helvetica, Arial, sans-serif; font-size: 16px; line-height: 1.5; color: #18181b;">
+{{.Content}}
+</td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>`))
+
+// MarkdownOptions configures how Markdown bodies are converted to HTML.
+type MarkdownOptions struct {
+	// Layout wraps the converted HTML. It is executed with a
+	// MarkdownLayoutData. DefaultMarkdownLayout is used if Layout is nil.
+	Layout *template.Template
+
+	// LinkStyle is set as the style attribute of every link, since many mail
+	// clients ignore stylesheets. DefaultMarkdownLinkStyle is used if
+	// LinkStyle is empty.
+	LinkStyle string
+}
+
+// MarkdownLayoutData is the data a Markdown layout is executed with.
+type MarkdownLayoutData struct {
+	Subject string
+	Content template.HTML
+}
+
+// RenderMarkdown converts the Markdown of params, which is CommonMark, to its
+// HTML body and, unless Text is already set, a matching plain-text body.
+// Markdown is cleared. Emails.Send calls RenderMarkdown for params with
+// Markdown set, using the options given to WithMarkdownOptions.
+//
+// Raw HTML in the Markdown is escaped, and only http, https, mailto and tel
+// links are kept.
+func RenderMarkdown(params *SendEmailParams, opts *MarkdownOptions) error {
+	if params.Markdown == "" {
+		return nil
+	}
+	if params.HTML != "" {
+		return FieldErrors{{Field: "markdown", Message: "cannot be used together with html"}}
+	}
+	if opts == nil {
+		opts = &MarkdownOptions{}
+	}
+
+	doc := markup.Parse(markdown.ToHTML(params.Markdown))
+	linkStyle := opts.LinkStyle
+	if linkStyle == "" {
+		linkStyle = DefaultMarkdownLinkStyle
+	}
+	doc.Walk(func(n *markup.Node) bool {
+		if n.Type == markup.ElementNode && n.Data == "a" {
+			n.SetAttr("style", linkStyle)
+		}
+		return true
+	})
+	content := markup.Render(doc)
+
+	layout := opts.Layout
+	if layout == nil {
+		layout = DefaultMarkdownLayout
+	}
+	var html strings.Builder
+	data := MarkdownLayoutData{Subject: params.Subject, Content: template.HTML(content)}
+	if err := layout.Execute(&html, data); err != nil {
+		return fmt.Errorf("mailbreeze: render markdown layout: %w", err)
+	}
+
+	params.HTML = html.String()
+	if params.Text == "" {
+		params.Text = markup.ToText(content)
+	}
+	params.Markdown = ""
+	return nil
+}
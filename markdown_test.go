@@ -0,0 +1,104 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	layout := template.Must(template.New("test").Parse(`<html><head><title>{{.Subject}}</title></head><body>{{.Content}}</body></html>`))
+	params := &SendEmailParams{
+		Subject:  "Your <order>",
+		Markdown: "# Thanks!\n\nYour order **#42** has shipped. [Track it](https://example.com/track).\n\n- Widget\n- Gadget",
+	}
+
+	err := RenderMarkdown(params, &MarkdownOptions{Layout: layout, LinkStyle: "color: red;"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantHTML := `<html><head><title>Your &lt;order&gt;</title></head><body><h1>Thanks!</h1>
+<p>Your order <strong>#42</strong> has shipped. <a href="https://example.com/track" style="color: red;">Track it</a>.</p>
+<ul>
+<li>Widget</li>
+<li>Gadget</li>
+</ul></body></html>`
+	if params.HTML != wantHTML {
+		t.Errorf("expected HTML\n%s\ngot\n%s", wantHTML, params.HTML)
+	}
+	wantText := "Thanks!\n\nYour order #42 has shipped. Track it (https://example.com/track).\n\n- Widget\n- Gadget"
+	if params.Text != wantText {
+		t.Errorf("expected text %q, got %q", wantText, params.Text)
+	}
+	if params.Markdown != "" {
+		t.Error("expected Markdown to be cleared")
+	}
+}
+
+func TestRenderMarkdownDefaults(t *testing.T) {
+	params := &SendEmailParams{Markdown: "See [docs](https://example.com/docs).", Text: "custom text"}
+	if err := RenderMarkdown(params, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(params.HTML, "<!DOCTYPE html>") {
+		t.Errorf("expected default layout, got %s", params.HTML)
+	}
+	if !strings.Contains(params.HTML, `<a href="https://example.com/docs" style="`+DefaultMarkdownLinkStyle+`">docs</a>`) {
+		t.Errorf("expected styled link, got %s", params.HTML)
+	}
+	if params.Text != "custom text" {
+		t.Errorf("expected Text to be kept, got %q", params.Text)
+	}
+}
+
+func TestRenderMarkdownWithHTML(t *testing.T) {
+	err := RenderMarkdown(&SendEmailParams{Markdown: "# Hi", HTML: "<h1>Hi</h1>"}, nil)
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) || fieldErrs[0].Field != "markdown" {
+		t.Errorf("expected markdown field error, got %v", err)
+	}
+}
+
+func TestEmailsSendMarkdown(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"messageId": "msg_123"},
+		})
+	}))
+	defer server.Close()
+
+	layout := template.Must(template.New("bare").Parse(`<div>{{.Content}}</div>`))
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMarkdownOptions(MarkdownOptions{Layout: layout}))
+
+	params := &SendEmailParams{
+		From:     "hello@example.com",
+		To:       []string{"user@example.com"},
+		Subject:  "Hello",
+		Markdown: "Hello *there*",
+	}
+	if _, err := client.Emails.Send(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["html"] != "<div><p>Hello <em>there</em></p></div>" || body["text"] != "Hello there" {
+		t.Errorf("unexpected body %v", body)
+	}
+	if _, ok := body["markdown"]; ok {
+		t.Error("expected markdown not to be sent")
+	}
+	if params.HTML != "" || params.Markdown == "" {
+		t.Error("expected caller's params to be unchanged")
+	}
+}
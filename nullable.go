@@ -0,0 +1,87 @@
+package mailbreeze
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a field of a partial update with three states: unset, null and
+// a value. Unset fields are left out of the request and keep their current
+// value, null fields are sent as JSON null and clear the current value, and
+// fields with a value are sent as that value.
+//
+// The zero value is unset. Use Value and Null to create set fields.
+type Nullable[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Value returns a Nullable set to v.
+func Value[T any](v T) Nullable[T] {
+	return Nullable[T]{value: v, set: true}
+}
+
+// Null returns a Nullable set to null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{set: true, null: true}
+}
+
+// IsSet reports whether n is null or has a value.
+func (n Nullable[T]) IsSet() bool {
+	return n.set
+}
+
+// IsNull reports whether n is set to null.
+func (n Nullable[T]) IsNull() bool {
+	return n.set && n.null
+}
+
+// Get returns the value of n and whether it has one.
+func (n Nullable[T]) Get() (T, bool) {
+	if !n.set || n.null {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+// MarshalJSON encodes null and unset fields as null and values as themselves.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.set || n.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON decodes null as a null field and anything else as a value.
+// Fields missing from the JSON object stay unset.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = Value(v)
+	return nil
+}
+
+// patchField is a named field of a partial update body.
+type patchField struct {
+	name  string
+	value interface{ IsSet() bool }
+}
+
+// patchBody returns the set fields as a JSON object, leaving out unset ones.
+func patchBody(fields ...patchField) map[string]interface{} {
+	body := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if field.value.IsSet() {
+			body[field.name] = field.value
+		}
+	}
+	return body
+}
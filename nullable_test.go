@@ -0,0 +1,142 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNullableStates(t *testing.T) {
+	var unset Nullable[string]
+	if unset.IsSet() || unset.IsNull() {
+		t.Error("expected zero value to be unset")
+	}
+	if _, ok := unset.Get(); ok {
+		t.Error("expected unset field to have no value")
+	}
+
+	null := Null[string]()
+	if !null.IsSet() || !null.IsNull() {
+		t.Error("expected Null to be set and null")
+	}
+	if _, ok := null.Get(); ok {
+		t.Error("expected null field to have no value")
+	}
+
+	value := Value("")
+	if !value.IsSet() || value.IsNull() {
+		t.Error("expected Value to be set and not null")
+	}
+	if v, ok := value.Get(); !ok || v != "" {
+		t.Errorf("expected empty string value, got %q, %v", v, ok)
+	}
+}
+
+func TestUpdateContactParamsMarshalJSON(t *testing.T) {
+	consent := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		params UpdateContactParams
+		want   string
+	}{
+		{
+			name:   "empty",
+			params: UpdateContactParams{},
+			want:   `{}`,
+		},
+		{
+			name:   "value",
+			params: UpdateContactParams{FirstName: Value("Jane")},
+			want:   `{"firstName":"Jane"}`,
+		},
+		{
+			name:   "empty string value",
+			params: UpdateContactParams{LastName: Value("")},
+			want:   `{"lastName":""}`,
+		},
+		{
+			name:   "null",
+			params: UpdateContactParams{PhoneNumber: Null[string](), ConsentTimestamp: Null[time.Time]()},
+			want:   `{"consentTimestamp":null,"phoneNumber":null}`,
+		},
+		{
+			name:   "time value",
+			params: UpdateContactParams{ConsentTimestamp: Value(consent), ConsentType: Value(ConsentTypeExplicit)},
+			want:   `{"consentTimestamp":"2024-01-01T00:00:00Z","consentType":"explicit"}`,
+		},
+		{
+			name:   "clear custom field",
+			params: UpdateContactParams{CustomFields: map[string]interface{}{"company": nil}},
+			want:   `{"customFields":{"company":null}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestUpdateContactParamsUnmarshalJSON(t *testing.T) {
+	var params UpdateContactParams
+	if err := json.Unmarshal([]byte(`{"firstName":"Jane","phoneNumber":null}`), &params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, ok := params.FirstName.Get(); !ok || v != "Jane" {
+		t.Errorf("expected firstName 'Jane', got %q", v)
+	}
+	if !params.PhoneNumber.IsNull() {
+		t.Error("expected phoneNumber to be null")
+	}
+	if params.LastName.IsSet() {
+		t.Error("expected lastName to be unset")
+	}
+}
+
+func TestListsUpdateClearDescription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if len(body) != 1 {
+			t.Errorf("expected only description in body, got %v", body)
+		}
+		if v, ok := body["description"]; !ok || v != nil {
+			t.Errorf("expected description null, got %v", v)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"id":        "list_123",
+				"name":      "My List",
+				"createdAt": "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	list, err := client.Lists.Update(context.Background(), "list_123", &UpdateListParams{
+		Description: Null[string](),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Description != "" {
+		t.Errorf("expected empty description, got %q", list.Description)
+	}
+}
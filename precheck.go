@@ -0,0 +1,268 @@
+package mailbreeze
+
+import (
+	_ "embed"
+	"net"
+	"strings"
+	"unicode/utf8"
+)
+
+// Precheck reasons reported in VerificationResult.Reason.
+const (
+	PrecheckReasonInvalidSyntax = "invalid_syntax"
+	PrecheckReasonDisposable    = "disposable"
+	PrecheckReasonPossibleTypo  = "possible_typo"
+	PrecheckReasonRoleAccount   = "role_account"
+)
+
+// Length limits from RFC 5321 section 4.5.3.1.
+const (
+	maxLocalPartLength = 64
+	maxDomainLength    = 253
+	maxLabelLength     = 63
+	maxAddressLength   = 254
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+var disposableDomains = parseDomainList(disposableDomainsFile)
+
+// roleAccounts are local parts that usually reach a group rather than a person.
+var roleAccounts = map[string]bool{
+	"abuse": true, "admin": true, "administrator": true, "billing": true,
+	"careers": true, "contact": true, "devnull": true, "dns": true,
+	"enquiries": true, "ftp": true, "hello": true, "help": true,
+	"hostmaster": true, "info": true, "inquiries": true, "jobs": true,
+	"mail": true, "mailer-daemon": true, "marketing": true, "media": true,
+	"news": true, "no-reply": true, "noc": true, "noreply": true,
+	"office": true, "postmaster": true, "press": true, "privacy": true,
+	"root": true, "sales": true, "security": true, "support": true,
+	"team": true, "webmaster": true,
+}
+
+// freeProviders are popular free mailbox providers.
+var freeProviders = map[string]bool{
+	"aol.com": true, "gmail.com": true, "gmx.com": true, "gmx.de": true,
+	"googlemail.com": true, "hotmail.com": true, "hotmail.co.uk": true,
+	"icloud.com": true, "live.com": true, "mail.com": true, "mail.ru": true,
+	"me.com": true, "msn.com": true, "outlook.com": true, "proton.me": true,
+	"protonmail.com": true, "yahoo.co.uk": true, "yahoo.com": true,
+	"yandex.com": true, "yandex.ru": true, "zoho.com": true,
+}
+
+// Precheck validates an email address offline, without spending verification
+// credits. It checks RFC 5321/5322 syntax (including internationalized
+// domains), bundled disposable domains, common provider typos and role
+// accounts.
+//
+// The returned result has status invalid when the address is certain to fail
+// verification, risky when it is disposable, a likely typo or a role account,
+// and unknown when it passes every local check and still needs to be verified
+// by the API. Disposable mailboxes do receive mail, so they are not invalid;
+// WithSkipDisposable skips them in batch verifications anyway.
+func Precheck(email string) *VerificationResult {
+	email = strings.TrimSpace(email)
+	result := &VerificationResult{
+		Email:   email,
+		Result:  VerificationStatusUnknown,
+		Details: &VerificationDetails{},
+	}
+
+	local, domain, ok := parseAddress(email)
+	if !ok {
+		result.Result = VerificationStatusInvalid
+		result.Reason = PrecheckReasonInvalidSyntax
+		result.RiskScore = 100
+		return result
+	}
+
+	result.Details.IsFreeProvider = freeProviders[domain]
+	result.Details.IsDisposable = isDisposableDomain(domain)
+	result.Details.IsRoleAccount = roleAccounts[strings.ToLower(local)]
+	if suggestion := suggestDomain(domain); suggestion != "" {
+		result.DidYouMean = local + "@" + suggestion
+	}
+
+	switch {
+	case result.Details.IsDisposable:
+		result.Result = VerificationStatusRisky
+		result.Reason = PrecheckReasonDisposable
+		result.RiskScore = 90
+	case result.DidYouMean != "":
+		result.Result = VerificationStatusRisky
+		result.Reason = PrecheckReasonPossibleTypo
+		result.RiskScore = 80
+	case result.Details.IsRoleAccount:
+		result.Result = VerificationStatusRisky
+		result.Reason = PrecheckReasonRoleAccount
+		result.RiskScore = 50
+	}
+
+	return result
+}
+
+// parseAddress splits an address into its local part and ASCII, lowercased
+// domain, reporting whether the address is syntactically valid.
+func parseAddress(email string) (local, domain string, ok bool) {
+	// Every rune takes at least one byte once the domain is encoded, so the
+	// rune count bounds the encoded length. Checking it first keeps
+	// oversized input away from the quadratic Punycode encoder.
+	if utf8.RuneCountInString(email) > maxAddressLength {
+		return "", "", false
+	}
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", "", false
+	}
+	local, domain = email[:at], email[at+1:]
+	if utf8.RuneCountInString(domain) > maxDomainLength {
+		return "", "", false
+	}
+
+	if !validLocalPart(local) {
+		return "", "", false
+	}
+
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		if !validAddressLiteral(domain[1 : len(domain)-1]) {
+			return "", "", false
+		}
+	} else {
+		var err error
+		domain, err = domainToASCII(domain)
+		if err != nil || !validDomain(domain) {
+			return "", "", false
+		}
+	}
+
+	if len(local)+1+len(domain) > maxAddressLength {
+		return "", "", false
+	}
+	return local, domain, true
+}
+
+// validLocalPart reports whether local is a valid dot-atom or quoted string.
+// UTF-8 characters are allowed as permitted by RFC 6531.
+func validLocalPart(local string) bool {
+	if len(local) > maxLocalPartLength || !utf8.ValidString(local) {
+		return false
+	}
+
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		return validQuotedString(local[1 : len(local)-1])
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			if !isAtext(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validQuotedString reports whether s is valid content of a quoted local part.
+func validQuotedString(s string) bool {
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			if r < 0x20 && r != '\t' || r == 0x7f {
+				return false
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			return false
+		case r < 0x20 || r == 0x7f:
+			return false
+		}
+	}
+	return !escaped
+}
+
+// isAtext reports whether r is an atext character (RFC 5322 section 3.2.3).
+func isAtext(r rune) bool {
+	if r >= utf8.RuneSelf {
+		return true
+	}
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
+
+// validDomain reports whether domain is a valid ASCII hostname with at least two labels.
+func validDomain(domain string) bool {
+	if len(domain) > maxDomainLength {
+		return false
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLength {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	// The top-level domain cannot be all-numeric
+	tld := labels[len(labels)-1]
+	return strings.Trim(tld, "0123456789") != ""
+}
+
+// validAddressLiteral reports whether s is a valid IPv4 or IPv6 address literal.
+func validAddressLiteral(s string) bool {
+	if v6, ok := strings.CutPrefix(s, "IPv6:"); ok {
+		ip := net.ParseIP(v6)
+		return ip != nil && strings.Contains(v6, ":")
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+}
+
+// isDisposableDomain reports whether domain or any parent domain is a known
+// disposable email domain.
+func isDisposableDomain(domain string) bool {
+	for {
+		if disposableDomains[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// parseDomainList parses a newline-separated domain list, skipping blank lines and comments.
+func parseDomainList(list string) map[string]bool {
+	domains := make(map[string]bool)
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains[strings.ToLower(line)] = true
+	}
+	return domains
+}
@@ -0,0 +1,150 @@
+package mailbreeze
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrecheckSyntax(t *testing.T) {
+	tests := []struct {
+		email string
+		valid bool
+	}{
+		{"user@example.com", true},
+		{"first.last+tag@sub.example.co.uk", true},
+		{"o'brien@example.com", true},
+		{"\"john doe\"@example.com", true},
+		{"\"john\\\"doe\"@example.com", true},
+		{"user@[192.168.0.1]", true},
+		{"user@[IPv6:2001:db8::1]", true},
+		{"用户@例子.广告", true},
+		{"josé@bücher.de", true},
+		{"  padded@example.com  ", true},
+		{"", false},
+		{"plainaddress", false},
+		{"@example.com", false},
+		{"user@", false},
+		{"user@localhost", false},
+		{"user@example..com", false},
+		{"user@-example.com", false},
+		{"user@example-.com", false},
+		{"user@example.123", false},
+		{"user@exa_mple.com", false},
+		{".user@example.com", false},
+		{"user.@example.com", false},
+		{"us..er@example.com", false},
+		{"us er@example.com", false},
+		{"user(comment)@example.com", false},
+		{"\"unterminated@example.com", false},
+		{"\"bad\"quote\"@example.com", false},
+		{"\"trailing\\\"@example.com", false},
+		{"user@[999.1.1.1]", false},
+		{"user@[IPv6:192.168.0.1]", false},
+		{"user@[2001:db8::1]", false},
+		{strings.Repeat("a", 65) + "@example.com", false},
+		{"user@" + strings.Repeat("a", 64) + ".com", false},
+		{"user@" + strings.Repeat("abcdefghi.", 26) + "com", false},
+		{strings.Repeat("a", 64) + "@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 63) + ".com", false},
+	}
+
+	for _, tt := range tests {
+		result := Precheck(tt.email)
+		invalidSyntax := result.Reason == PrecheckReasonInvalidSyntax
+		if invalidSyntax == tt.valid {
+			t.Errorf("Precheck(%q): expected valid=%v, got reason %q", tt.email, tt.valid, result.Reason)
+		}
+		if !tt.valid && result.Result != VerificationStatusInvalid {
+			t.Errorf("Precheck(%q): expected invalid status, got %q", tt.email, result.Result)
+		}
+	}
+}
+
+func TestPrecheckOversizedInput(t *testing.T) {
+	// Distinct runes are the worst case for Punycode encoding
+	var domain strings.Builder
+	for r := rune(0x4e00); r < 0x4e00+20000; r++ {
+		domain.WriteRune(r)
+	}
+	email := "user@" + domain.String() + ".com"
+
+	start := time.Now()
+	result := Precheck(email)
+	if result.Reason != PrecheckReasonInvalidSyntax {
+		t.Errorf("expected invalid syntax, got %q", result.Reason)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("expected oversized input to be rejected quickly, took %v", elapsed)
+	}
+}
+
+func TestPrecheckVerdicts(t *testing.T) {
+	tests := []struct {
+		email  string
+		status VerificationStatus
+		reason string
+	}{
+		{"user@example.com", VerificationStatusUnknown, ""},
+		{"user@mailinator.com", VerificationStatusRisky, PrecheckReasonDisposable},
+		{"user@eu.mailinator.com", VerificationStatusRisky, PrecheckReasonDisposable},
+		{"user@YOPMAIL.com", VerificationStatusRisky, PrecheckReasonDisposable},
+		{"user@gmial.com", VerificationStatusRisky, PrecheckReasonPossibleTypo},
+		{"Support@example.com", VerificationStatusRisky, PrecheckReasonRoleAccount},
+		{"noreply@mailinator.com", VerificationStatusRisky, PrecheckReasonDisposable},
+	}
+
+	for _, tt := range tests {
+		result := Precheck(tt.email)
+		if result.Result != tt.status {
+			t.Errorf("Precheck(%q): expected status %q, got %q", tt.email, tt.status, result.Result)
+		}
+		if result.Reason != tt.reason {
+			t.Errorf("Precheck(%q): expected reason %q, got %q", tt.email, tt.reason, result.Reason)
+		}
+		if result.IsValid {
+			t.Errorf("Precheck(%q): expected IsValid to be false", tt.email)
+		}
+	}
+}
+
+func TestPrecheckDetails(t *testing.T) {
+	result := Precheck("info@gmail.com")
+
+	if !result.Details.IsFreeProvider {
+		t.Error("expected gmail.com to be a free provider")
+	}
+	if !result.Details.IsRoleAccount {
+		t.Error("expected info to be a role account")
+	}
+	if result.Details.IsDisposable {
+		t.Error("expected gmail.com not to be disposable")
+	}
+	if result.Email != "info@gmail.com" {
+		t.Errorf("expected email 'info@gmail.com', got '%s'", result.Email)
+	}
+}
+
+func TestDomainToASCII(t *testing.T) {
+	tests := map[string]string{
+		"example.com":           "example.com",
+		"Example.COM":           "example.com",
+		"bücher.de":             "xn--bcher-kva.de",
+		"münchen.de":            "xn--mnchen-3ya.de",
+		"例子.广告":                 "xn--fsqu00a.xn--4rr70v",
+		"ليهمابتكلموشعربي؟.com": "xn--egbpdaj6bu4bxfgehfvwxn.com",
+	}
+
+	for input, expected := range tests {
+		got, err := domainToASCII(input)
+		if err != nil {
+			t.Fatalf("domainToASCII(%q): unexpected error: %v", input, err)
+		}
+		if got != expected {
+			t.Errorf("domainToASCII(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+
+	if _, err := domainToASCII("bad\xffdomain.com"); err == nil {
+		t.Error("expected error for invalid UTF-8")
+	}
+}
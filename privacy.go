@@ -0,0 +1,246 @@
+package mailbreeze
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// PrivacyResource answers data-subject access and erasure requests.
+type PrivacyResource struct {
+	client *HTTPClient
+}
+
+// PrivacyContact is a contact holding the data subject's email, with the list it belongs to.
+type PrivacyContact struct {
+	ListID   string  `json:"listId"`
+	ListName string  `json:"listName"`
+	Contact  Contact `json:"contact"`
+}
+
+// ConsentRecord is the consent a contact gave to be added to a list.
+type ConsentRecord struct {
+	ListID    string      `json:"listId"`
+	ContactID string      `json:"contactId"`
+	Type      ConsentType `json:"type,omitempty"`
+	Source    string      `json:"source,omitempty"`
+	Timestamp *time.Time  `json:"timestamp,omitempty"`
+	IPAddress string      `json:"ipAddress,omitempty"`
+}
+
+// PrivacyVerification is a verification result for the data subject's email.
+type PrivacyVerification struct {
+	// VerificationID is the batch verification the result belongs to.
+	VerificationID string             `json:"verificationId"`
+	VerifiedAt     time.Time          `json:"verifiedAt"`
+	Result         VerificationResult `json:"result"`
+}
+
+// PrivacyExport is every record held about an email address.
+type PrivacyExport struct {
+	Email         string                `json:"email"`
+	GeneratedAt   time.Time             `json:"generatedAt"`
+	Contacts      []PrivacyContact      `json:"contacts"`
+	Emails        []Email               `json:"emails"`
+	Verifications []PrivacyVerification `json:"verifications"`
+	Consents      []ConsentRecord       `json:"consents"`
+}
+
+// Export gathers the contacts in every list, the emails sent, the batch
+// verification results and the consent records for email into a single
+// document that can be encoded as JSON.
+//
+// Lists, emails and verifications are paged through in full, and
+// verifications listed without their results are fetched one by one, so
+// Export makes many requests on large accounts.
+func (r *PrivacyResource) Export(ctx context.Context, email string) (*PrivacyExport, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("mailbreeze: email is required")
+	}
+
+	export := &PrivacyExport{
+		Email:         email,
+		GeneratedAt:   time.Now().UTC(),
+		Contacts:      []PrivacyContact{},
+		Emails:        []Email{},
+		Verifications: []PrivacyVerification{},
+		Consents:      []ConsentRecord{},
+	}
+
+	contacts, err := r.findContacts(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	for _, pc := range contacts {
+		export.Contacts = append(export.Contacts, pc)
+		export.Consents = append(export.Consents, ConsentRecord{
+			ListID:    pc.ListID,
+			ContactID: pc.Contact.ID,
+			Type:      pc.Contact.ConsentType,
+			Source:    pc.Contact.ConsentSource,
+			Timestamp: pc.Contact.ConsentTimestamp,
+			IPAddress: pc.Contact.ConsentIpAddress,
+		})
+	}
+
+	emails := &EmailsResource{client: r.client}
+	err = emails.eachEmail(ctx, func(sent *Email) error {
+		if sentTo(sent, email) {
+			export.Emails = append(export.Emails, *sent)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &VerificationResource{client: r.client}
+	err = verification.eachVerification(ctx, func(batch *BatchVerificationResult) error {
+		if batch.Results == nil {
+			// Listed verifications are summaries without per-address results
+			full, err := verification.Get(ctx, batch.VerificationID)
+			if err != nil {
+				return err
+			}
+			batch = full
+		}
+		results := batch.Results.Detailed()
+		if grouped := batch.Results.Grouped(); grouped != nil {
+			results = groupedToDetailed(grouped)
+		}
+		for _, result := range results {
+			if strings.EqualFold(result.Email, email) {
+				export.Verifications = append(export.Verifications, PrivacyVerification{
+					VerificationID: batch.VerificationID,
+					VerifiedAt:     batch.CreatedAt,
+					Result:         result,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// ErasureActionType is the action Erase took on a contact.
+type ErasureActionType string
+
+const (
+	ErasureActionDeleted    ErasureActionType = "deleted"
+	ErasureActionSuppressed ErasureActionType = "suppressed"
+	ErasureActionFailed     ErasureActionType = "failed"
+)
+
+// ErasureAction records what Erase did to one contact.
+type ErasureAction struct {
+	ListID    string            `json:"listId"`
+	ContactID string            `json:"contactId"`
+	Action    ErasureActionType `json:"action"`
+	At        time.Time         `json:"at"`
+
+	// Error is the error returned by the API when the contact could not be deleted.
+	Error string `json:"error,omitempty"`
+}
+
+// ErasureReceipt is the audit record of an Erase call.
+type ErasureReceipt struct {
+	Email       string          `json:"email"`
+	RequestedAt time.Time       `json:"requestedAt"`
+	CompletedAt time.Time       `json:"completedAt"`
+	Actions     []ErasureAction `json:"actions"`
+
+	// Failed is the number of contacts that could be neither deleted nor suppressed.
+	Failed int `json:"failed"`
+
+	// Suppressed reports whether any contact was suppressed rather than
+	// deleted. The API has no suppression list apart from contacts, so once
+	// every contact is deleted nothing stops the address from being imported
+	// or subscribed again; keep your own record if it must not be.
+	Suppressed bool `json:"suppressed"`
+}
+
+// Erase deletes the contacts holding email from every list. Contacts that
+// cannot be deleted are suppressed instead so they receive no further email.
+// Every contact is attempted; the receipt records the outcome of each one and
+// whether the address is left suppressed anywhere.
+//
+// Erase returns an error only when the lists or contacts cannot be looked up.
+func (r *PrivacyResource) Erase(ctx context.Context, email string) (*ErasureReceipt, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("mailbreeze: email is required")
+	}
+
+	receipt := &ErasureReceipt{
+		Email:       email,
+		RequestedAt: time.Now().UTC(),
+		Actions:     []ErasureAction{},
+	}
+
+	contacts, err := r.findContacts(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pc := range contacts {
+		resource := &ContactsResource{client: r.client, listID: pc.ListID}
+		action := ErasureAction{ListID: pc.ListID, ContactID: pc.Contact.ID, Action: ErasureActionDeleted}
+
+		if err := resource.Delete(ctx, pc.Contact.ID); err != nil {
+			action.Error = err.Error()
+			action.Action = ErasureActionSuppressed
+			if err := resource.Suppress(ctx, pc.Contact.ID, SuppressReasonManual); err != nil {
+				action.Action = ErasureActionFailed
+				action.Error += "; " + err.Error()
+				receipt.Failed++
+			} else {
+				receipt.Suppressed = true
+			}
+		}
+
+		action.At = time.Now().UTC()
+		receipt.Actions = append(receipt.Actions, action)
+	}
+
+	receipt.CompletedAt = time.Now().UTC()
+	return receipt, nil
+}
+
+// findContacts returns every contact holding email, ignoring case, in every
+// list. A list can hold several contacts whose emails differ only in case.
+func (r *PrivacyResource) findContacts(ctx context.Context, email string) ([]PrivacyContact, error) {
+	var contacts []PrivacyContact
+	lists := &ListsResource{client: r.client}
+	err := lists.eachList(ctx, func(list *List) error {
+		resource := &ContactsResource{client: r.client, listID: list.ID}
+		return resource.eachContact(ctx, &ListContactsParams{Search: email}, func(contact *Contact) error {
+			if strings.EqualFold(contact.Email, email) {
+				contacts = append(contacts, PrivacyContact{ListID: list.ID, ListName: list.Name, Contact: *contact})
+			}
+			return nil
+		})
+	})
+	return contacts, err
+}
+
+// sentTo reports whether email is among the recipients of sent.
+func sentTo(sent *Email, email string) bool {
+	for _, recipients := range [][]string{sent.To, sent.CC, sent.BCC} {
+		for _, recipient := range recipients {
+			if addr, err := mail.ParseAddress(recipient); err == nil {
+				recipient = addr.Address
+			}
+			if strings.EqualFold(strings.TrimSpace(recipient), email) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newPrivacyServer serves three lists: list_1 holds jane@example.com twice,
+// with different case, list_3 holds her once, and deleting her contact from
+// list_3 is forbidden.
+func newPrivacyServer(t *testing.T, calls *[]string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		respond := func(status int, data interface{}) {
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": status < 300, "data": data})
+		}
+		page := func(items interface{}) map[string]interface{} {
+			return map[string]interface{}{"data": items, "pagination": map[string]interface{}{"hasNext": false}}
+		}
+		contact := func(id, email string) map[string]interface{} {
+			return map[string]interface{}{
+				"id":               id,
+				"email":            email,
+				"status":           "active",
+				"consentType":      "explicit",
+				"consentSource":    "signup_form",
+				"consentTimestamp": "2024-01-02T03:04:05Z",
+				"createdAt":        "2024-01-01T00:00:00Z",
+			}
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/contact-lists":
+			respond(http.StatusOK, []map[string]interface{}{
+				{"id": "list_1", "name": "Newsletter"},
+				{"id": "list_2", "name": "Customers"},
+				{"id": "list_3", "name": "Events"},
+			})
+
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/contacts"):
+			if r.URL.Query().Get("search") != "jane@example.com" {
+				t.Errorf("expected search for jane@example.com, got %s", r.URL.Query().Get("search"))
+			}
+			switch r.URL.Path {
+			case "/api/v1/contact-lists/list_1/contacts":
+				respond(http.StatusOK, page([]interface{}{
+					contact("c1", "Jane@example.com"),
+					contact("c1b", "jane@EXAMPLE.com"),
+					contact("c1c", "jane@example.com.au"),
+				}))
+			case "/api/v1/contact-lists/list_3/contacts":
+				respond(http.StatusOK, page([]interface{}{contact("c3", "Jane@example.com")}))
+			default:
+				respond(http.StatusOK, page([]interface{}{}))
+			}
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/emails":
+			respond(http.StatusOK, page([]map[string]interface{}{
+				{"id": "e1", "from": "a@b.com", "to": []string{"Jane <jane@example.com>"}, "status": "delivered"},
+				{"id": "e2", "from": "a@b.com", "to": []string{"john@example.com"}, "status": "delivered"},
+				{"id": "e3", "from": "a@b.com", "to": []string{"john@example.com"}, "bcc": []string{"JANE@example.com"}, "status": "sent"},
+			}))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/email-verification":
+			respond(http.StatusOK, page([]map[string]interface{}{
+				{
+					"verificationId": "ver_1",
+					"status":         "completed",
+					"createdAt":      "2024-02-01T00:00:00Z",
+					"results": []map[string]interface{}{
+						{"email": "jane@example.com", "isValid": true, "result": "valid"},
+						{"email": "john@example.com", "isValid": true, "result": "valid"},
+					},
+				},
+				{
+					"verificationId": "ver_2",
+					"status":         "completed",
+					"createdAt":      "2024-03-01T00:00:00Z",
+					"results":        map[string]interface{}{"clean": []string{"jane@example.com"}},
+				},
+				{
+					"verificationId": "ver_3",
+					"status":         "completed",
+					"createdAt":      "2024-04-01T00:00:00Z",
+				},
+			}))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/email-verification/ver_3":
+			respond(http.StatusOK, map[string]interface{}{
+				"verificationId": "ver_3",
+				"status":         "completed",
+				"createdAt":      "2024-04-01T00:00:00Z",
+				"results": []map[string]interface{}{
+					{"email": "jane@example.com", "result": "risky"},
+				},
+			})
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/contact-lists/list_3/contacts/c3":
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "FORBIDDEN", "message": "Contact is locked"},
+			})
+
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/suppress"):
+			respond(http.StatusOK, nil)
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestPrivacyExport(t *testing.T) {
+	var calls []string
+	server := newPrivacyServer(t, &calls)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	export, err := client.Privacy.Export(context.Background(), " jane@example.com ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if export.Email != "jane@example.com" {
+		t.Errorf("expected email jane@example.com, got %s", export.Email)
+	}
+	if len(export.Contacts) != 3 || export.Contacts[0].ListName != "Newsletter" || export.Contacts[1].Contact.ID != "c1b" || export.Contacts[2].ListID != "list_3" {
+		t.Errorf("unexpected contacts %+v", export.Contacts)
+	}
+	if len(export.Consents) != 3 || export.Consents[0].Type != ConsentTypeExplicit || export.Consents[0].Source != "signup_form" {
+		t.Errorf("unexpected consents %+v", export.Consents)
+	}
+	if len(export.Emails) != 2 || export.Emails[0].ID != "e1" || export.Emails[1].ID != "e3" {
+		t.Errorf("unexpected emails %+v", export.Emails)
+	}
+	if len(export.Verifications) != 3 || export.Verifications[0].VerificationID != "ver_1" ||
+		export.Verifications[1].Result.Result != VerificationStatusValid ||
+		export.Verifications[2].VerificationID != "ver_3" || export.Verifications[2].Result.Result != VerificationStatusRisky {
+		t.Errorf("unexpected verifications %+v", export.Verifications)
+	}
+
+	for _, call := range calls {
+		if !strings.HasPrefix(call, "GET ") {
+			t.Errorf("expected export to only read, got %s", call)
+		}
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("failed to encode export: %v", err)
+	}
+	if !strings.Contains(string(data), `"consents":[`) {
+		t.Errorf("expected consents in JSON, got %s", data)
+	}
+}
+
+func TestPrivacyErase(t *testing.T) {
+	var calls []string
+	server := newPrivacyServer(t, &calls)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	receipt, err := client.Privacy.Erase(context.Background(), "jane@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(receipt.Actions) != 3 {
+		t.Fatalf("expected 3 actions, got %+v", receipt.Actions)
+	}
+	if a := receipt.Actions[0]; a.ListID != "list_1" || a.ContactID != "c1" || a.Action != ErasureActionDeleted || a.Error != "" {
+		t.Errorf("unexpected first action %+v", a)
+	}
+	if a := receipt.Actions[1]; a.ContactID != "c1b" || a.Action != ErasureActionDeleted {
+		t.Errorf("expected the contact differing only in case to be deleted, got %+v", a)
+	}
+	if a := receipt.Actions[2]; a.ContactID != "c3" || a.Action != ErasureActionSuppressed || !strings.Contains(a.Error, "Contact is locked") {
+		t.Errorf("unexpected second action %+v", a)
+	}
+	if receipt.Failed != 0 {
+		t.Errorf("expected no failures, got %d", receipt.Failed)
+	}
+	if !receipt.Suppressed {
+		t.Error("expected the receipt to record that c3 was suppressed")
+	}
+	if receipt.CompletedAt.Before(receipt.RequestedAt) {
+		t.Error("expected completedAt after requestedAt")
+	}
+
+	var suppressed bool
+	for _, call := range calls {
+		if call == "POST /api/v1/contact-lists/list_3/contacts/c3/suppress" {
+			suppressed = true
+		}
+	}
+	if !suppressed {
+		t.Errorf("expected c3 to be suppressed, got calls %v", calls)
+	}
+}
+
+func TestPrivacyRequiresEmail(t *testing.T) {
+	client := NewClient("sk_test_123")
+
+	if _, err := client.Privacy.Export(context.Background(), " "); err == nil {
+		t.Error("expected error for empty email")
+	}
+	if _, err := client.Privacy.Erase(context.Background(), ""); err == nil {
+		t.Error("expected error for empty email")
+	}
+}
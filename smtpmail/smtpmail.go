@@ -0,0 +1,234 @@
+// Package smtpmail sends mail through the MailBreeze API with the same shapes
+// as net/smtp, so code written against net/smtp can switch providers by
+// changing an import and a constructor.
+//
+// Client.SendMail has the signature of smtp.SendMail:
+//
+//	send := smtp.SendMail
+//	send := smtpmail.NewClient(mb).SendMail
+//
+// and Client has the methods of smtp.Client used to send a message, with
+// Data returning an io.WriteCloser that sends the message on Close:
+//
+//	c, err := smtp.Dial("mail.example.com:25")
+//	c := smtpmail.NewClient(mb)
+//
+// Messages are parsed with mailbreeze.ParseRawMessage, and the envelope
+// sender and recipients are applied with RawMessage.SetEnvelope. Addresses,
+// authentication and TLS settings meant for an SMTP server are ignored.
+package smtpmail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/smtp"
+	"strings"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+// ErrClosed is returned when a Client or message writer is used after Close.
+var ErrClosed = errors.New("smtpmail: use of closed client or writer")
+
+// Client sends messages through Client.Emails of a MailBreeze client.
+type Client struct {
+	emails *mailbreeze.EmailsResource
+	ctx    context.Context
+
+	from   string
+	rcpts  []string
+	mail   bool
+	closed bool
+}
+
+// NewClient returns a Client that sends with client.
+func NewClient(client *mailbreeze.Client) *Client {
+	return &Client{emails: client.Emails, ctx: context.Background()}
+}
+
+// WithContext returns a copy of c whose sends use ctx. The copy has no
+// transaction in progress.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return &Client{emails: c.emails, ctx: ctx}
+}
+
+// SendMail sends msg from the envelope sender from to the recipients to, like
+// smtp.SendMail. addr and a are accepted for compatibility and ignored.
+func (c *Client) SendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	return c.SendMailContext(c.ctx, from, to, msg)
+}
+
+// SendMailContext is SendMail with a context and without the SMTP server
+// arguments.
+func (c *Client) SendMailContext(ctx context.Context, from string, to []string, msg []byte) error {
+	if err := validateLine(from); err != nil {
+		return err
+	}
+	if len(to) == 0 {
+		return errors.New("smtpmail: no recipients")
+	}
+	for _, rcpt := range to {
+		if err := validateLine(rcpt); err != nil {
+			return err
+		}
+	}
+	return send(ctx, c.emails, from, to, msg)
+}
+
+// Hello is a no-op for compatibility with smtp.Client.
+func (c *Client) Hello(localName string) error {
+	return c.check()
+}
+
+// StartTLS is a no-op for compatibility with smtp.Client; the API is always
+// used over HTTPS.
+func (c *Client) StartTLS(config *tls.Config) error {
+	return c.check()
+}
+
+// Auth is a no-op for compatibility with smtp.Client; the API key of the
+// MailBreeze client is used instead.
+func (c *Client) Auth(a smtp.Auth) error {
+	return c.check()
+}
+
+// Extension reports whether an SMTP extension is supported. Only the
+// extensions that affect how callers format messages are reported.
+func (c *Client) Extension(ext string) (bool, string) {
+	switch strings.ToUpper(ext) {
+	case "8BITMIME", "SMTPUTF8":
+		return true, ""
+	}
+	return false, ""
+}
+
+// Mail starts a message with the envelope sender from.
+func (c *Client) Mail(from string) error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	if err := validateLine(from); err != nil {
+		return err
+	}
+	c.from, c.rcpts, c.mail = from, nil, true
+	return nil
+}
+
+// Rcpt adds an envelope recipient to the message started with Mail.
+func (c *Client) Rcpt(to string) error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	if !c.mail {
+		return errors.New("smtpmail: Rcpt called before Mail")
+	}
+	if err := validateLine(to); err != nil {
+		return err
+	}
+	c.rcpts = append(c.rcpts, to)
+	return nil
+}
+
+// Data returns a writer for the message. The message is sent when the writer
+// is closed, and Close returns any error from sending it.
+func (c *Client) Data() (io.WriteCloser, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	if len(c.rcpts) == 0 {
+		return nil, errors.New("smtpmail: Data called before Rcpt")
+	}
+	w := NewWriter(c.ctx, c.emails, c.from, c.rcpts)
+	c.from, c.rcpts, c.mail = "", nil, false
+	return w, nil
+}
+
+// Reset aborts the message started with Mail.
+func (c *Client) Reset() error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	c.from, c.rcpts, c.mail = "", nil, false
+	return nil
+}
+
+// Noop is a no-op for compatibility with smtp.Client.
+func (c *Client) Noop() error {
+	return c.check()
+}
+
+// Quit closes the client.
+func (c *Client) Quit() error {
+	return c.Close()
+}
+
+// Close closes the client. Messages already sent are not affected.
+func (c *Client) Close() error {
+	if c.closed {
+		return ErrClosed
+	}
+	c.closed = true
+	return nil
+}
+
+func (c *Client) check() error {
+	if c.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+// Writer buffers an RFC 5322 message and sends it on Close.
+type Writer struct {
+	emails *mailbreeze.EmailsResource
+	ctx    context.Context
+	from   string
+	to     []string
+	buf    bytes.Buffer
+	closed bool
+}
+
+// NewWriter returns a Writer that sends the message written to it from the
+// envelope sender from to the recipients to.
+func NewWriter(ctx context.Context, emails *mailbreeze.EmailsResource, from string, to []string) *Writer {
+	return &Writer{emails: emails, ctx: ctx, from: from, to: to}
+}
+
+// Write appends p to the message.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrClosed
+	}
+	return w.buf.Write(p)
+}
+
+// Close sends the message.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	return send(w.ctx, w.emails, w.from, w.to, w.buf.Bytes())
+}
+
+func send(ctx context.Context, emails *mailbreeze.EmailsResource, from string, to []string, msg []byte) error {
+	raw, err := mailbreeze.ParseRawMessage(bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	raw.SetEnvelope(from, to)
+	_, err = emails.SendRawMessage(ctx, raw)
+	return err
+}
+
+// validateLine rejects addresses that would break an SMTP command line, as
+// net/smtp does.
+func validateLine(line string) error {
+	if strings.ContainsAny(line, "\r\n") {
+		return errors.New("smtpmail: a line must not contain CR or LF")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package smtpmail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+func newTestClient(t *testing.T, status int) (*Client, *[]mailbreeze.SendEmailParams) {
+	var sent []mailbreeze.SendEmailParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params mailbreeze.SendEmailParams
+		json.NewDecoder(r.Body).Decode(&params)
+		sent = append(sent, params)
+
+		w.WriteHeader(status)
+		if status >= 400 {
+			fmt.Fprint(w, `{"success":false,"error":{"code":"VALIDATION_ERROR","message":"invalid"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"success":true,"data":{"messageId":"msg_%d"}}`, len(sent))
+	}))
+	t.Cleanup(server.Close)
+
+	mb := mailbreeze.NewClient("sk_test", mailbreeze.WithBaseURL(server.URL), mailbreeze.WithMaxRetries(0))
+	return NewClient(mb), &sent
+}
+
+const testMessage = "To: bob@example.com\r\n" +
+	"Subject: Hello\r\n" +
+	"\r\n" +
+	"Hi Bob\r\n"
+
+func TestSendMail(t *testing.T) {
+	c, sent := newTestClient(t, http.StatusCreated)
+
+	var sendMail func(string, smtp.Auth, string, []string, []byte) error = c.SendMail
+	auth := smtp.PlainAuth("", "user", "password", "smtp.example.com")
+	err := sendMail("smtp.example.com:587", auth, "jane@example.com", []string{"bob@example.com", "hidden@example.com"}, []byte(testMessage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(*sent))
+	}
+	p := (*sent)[0]
+	if p.From != "jane@example.com" || p.Subject != "Hello" {
+		t.Errorf("unexpected params %+v", p)
+	}
+	if len(p.To) != 1 || len(p.BCC) != 1 || p.BCC[0] != "hidden@example.com" {
+		t.Errorf("unexpected To %q / BCC %q", p.To, p.BCC)
+	}
+}
+
+func TestSendMailErrors(t *testing.T) {
+	c, sent := newTestClient(t, http.StatusBadRequest)
+
+	err := c.SendMail("", nil, "jane@example.com", []string{"bob@example.com"}, []byte(testMessage))
+	var apiErr *mailbreeze.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected API error, got %v", err)
+	}
+
+	if err := c.SendMail("", nil, "jane@example.com\r\nRCPT TO:<x@example.com>", []string{"bob@example.com"}, nil); err == nil {
+		t.Error("expected error for CRLF in sender")
+	}
+	if err := c.SendMail("", nil, "jane@example.com", nil, []byte(testMessage)); err == nil {
+		t.Error("expected error for no recipients")
+	}
+	if len(*sent) != 1 {
+		t.Errorf("expected invalid calls not to reach the API, got %d requests", len(*sent))
+	}
+}
+
+func TestClientData(t *testing.T) {
+	c, sent := newTestClient(t, http.StatusCreated)
+
+	if _, err := c.Data(); err == nil {
+		t.Error("expected error for Data before Rcpt")
+	}
+	if err := c.Rcpt("bob@example.com"); err == nil {
+		t.Error("expected error for Rcpt before Mail")
+	}
+
+	if err := c.Hello("localhost"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Auth(smtp.PlainAuth("", "user", "password", "localhost")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Mail("jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Rcpt("bob@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(w, testMessage)
+
+	if len(*sent) != 0 {
+		t.Fatal("expected message not to be sent before Close")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*sent) != 1 || (*sent)[0].Text != "Hi Bob\r\n" {
+		t.Errorf("unexpected sent emails %+v", *sent)
+	}
+	if _, err := w.Write([]byte("more")); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+
+	if err := c.Quit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Mail("jane@example.com"); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Quit, got %v", err)
+	}
+}
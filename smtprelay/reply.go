@@ -0,0 +1,73 @@
+package smtprelay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+// Reply is an SMTP reply.
+type Reply struct {
+	// Code is the three-digit reply code, such as 250 or 451.
+	Code int
+
+	// Enhanced is the RFC 3463 enhanced status code, such as "4.3.0". It may
+	// be empty.
+	Enhanced string
+
+	Message string
+}
+
+// String formats the reply as a single reply line, without the line ending.
+func (r Reply) String() string {
+	// Replies are one line; the message may come from the API
+	msg := strings.Join(strings.Fields(r.Message), " ")
+	if r.Enhanced == "" {
+		return strconv.Itoa(r.Code) + " " + msg
+	}
+	return strconv.Itoa(r.Code) + " " + r.Enhanced + " " + msg
+}
+
+// Temporary reports whether the reply is a transient (4xx) failure, after
+// which the client should retry.
+func (r Reply) Temporary() bool {
+	return r.Code >= 400 && r.Code < 500
+}
+
+// ReplyForError returns the SMTP reply for an error from sending a message.
+// Rate limits, server errors and network failures are transient 4xx
+// replies; authentication, permission and validation errors are permanent
+// 5xx replies.
+func ReplyForError(err error) Reply {
+	var apiErr *mailbreeze.Error
+	if errors.As(err, &apiErr) {
+		switch code := apiErr.StatusCode; {
+		case code == http.StatusTooManyRequests:
+			return Reply{451, "4.7.0", "Rate limit exceeded, try again later"}
+		case code >= 500:
+			return Reply{451, "4.3.0", "Temporary failure: " + apiErr.Message}
+		case code == http.StatusUnauthorized:
+			// 535 is reserved for AUTH replies (RFC 4954)
+			return Reply{554, "5.7.8", "Authentication credentials invalid"}
+		case code == http.StatusForbidden:
+			return Reply{550, "5.7.1", "Not permitted: " + apiErr.Message}
+		case code == http.StatusRequestEntityTooLarge:
+			return Reply{552, "5.3.4", "Message too large: " + apiErr.Message}
+		default:
+			return Reply{554, "5.6.0", "Message rejected: " + apiErr.Message}
+		}
+	}
+
+	var fieldErrs mailbreeze.FieldErrors
+	if errors.As(err, &fieldErrs) {
+		return Reply{554, "5.6.0", "Message rejected: " + strings.TrimPrefix(fieldErrs.Error(), "mailbreeze: ")}
+	}
+	if errors.Is(err, context.Canceled) {
+		return Reply{421, "4.3.2", "Service shutting down"}
+	}
+	return Reply{451, "4.4.1", "Temporary failure, try again later"}
+}
@@ -0,0 +1,40 @@
+package smtprelay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+func TestReplyForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"rate limit", &mailbreeze.Error{StatusCode: 429}, "451 4.7.0 Rate limit exceeded, try again later"},
+		{"server error", &mailbreeze.Error{StatusCode: 502, Message: "bad\ngateway"}, "451 4.3.0 Temporary failure: bad gateway"},
+		{"unauthorized", &mailbreeze.Error{StatusCode: 401}, "554 5.7.8 Authentication credentials invalid"},
+		{"forbidden", &mailbreeze.Error{StatusCode: 403, Message: "domain not verified"}, "550 5.7.1 Not permitted: domain not verified"},
+		{"validation", &mailbreeze.Error{StatusCode: 400, Message: "invalid to"}, "554 5.6.0 Message rejected: invalid to"},
+		{"wrapped", fmt.Errorf("upload: %w", &mailbreeze.Error{StatusCode: 413, Message: "too big"}), "552 5.3.4 Message too large: too big"},
+		{"field errors", mailbreeze.FieldErrors{{Field: "markdown", Message: "bad"}}, "554 5.6.0 Message rejected: invalid fields: markdown: bad"},
+		{"shutdown", context.Canceled, "421 4.3.2 Service shutting down"},
+		{"network", errors.New("connection refused"), "451 4.4.1 Temporary failure, try again later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := ReplyForError(tt.err)
+			if reply.String() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, reply.String())
+			}
+			if reply.Temporary() != (tt.want[0] == '4') {
+				t.Errorf("unexpected Temporary() for %q", tt.want)
+			}
+		})
+	}
+}
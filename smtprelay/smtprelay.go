@@ -0,0 +1,621 @@
+// Package smtprelay is an SMTP submission server that forwards messages to
+// the MailBreeze API, for applications that can only send email over SMTP.
+//
+// Clients authenticate with AUTH PLAIN or AUTH LOGIN using their MailBreeze
+// API key as the password; the username is ignored. The key is checked with
+// the API before AUTH succeeds, so a bad key fails at AUTH instead of
+// bouncing the message. Each message is parsed with mailbreeze.ParseRawMessage
+// and sent with Emails.SendRawMessage, and API errors are returned as SMTP
+// replies: 4xx for errors worth retrying and 5xx for rejected messages.
+//
+//	srv := &smtprelay.Server{Addr: ":2525", TLSConfig: tlsConfig}
+//	log.Fatal(srv.ListenAndServe())
+package smtprelay
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+// DefaultMaxMessageBytes is the largest message accepted when
+// Server.MaxMessageBytes is zero.
+const DefaultMaxMessageBytes = 25 << 20
+
+// DefaultTimeout is the read and write timeout of a connection when
+// Server.Timeout is zero.
+const DefaultTimeout = 5 * time.Minute
+
+// ErrServerClosed is returned by Serve and ListenAndServe after Close.
+var ErrServerClosed = errors.New("smtprelay: server closed")
+
+// Server is an SMTP server that relays messages through the MailBreeze API.
+type Server struct {
+	// Addr is the TCP address to listen on, ":2525" if empty.
+	Addr string
+
+	// Hostname is the name the server greets clients with. It defaults to
+	// the host name of the machine.
+	Hostname string
+
+	// TLSConfig enables STARTTLS when set.
+	TLSConfig *tls.Config
+
+	// AllowInsecureAuth allows AUTH on connections that have not started
+	// TLS, which exposes API keys on the network. Use it only on loopback
+	// interfaces or trusted networks.
+	AllowInsecureAuth bool
+
+	// MaxMessageBytes limits the size of a message, DefaultMaxMessageBytes
+	// if zero.
+	MaxMessageBytes int64
+
+	// Timeout is the read and write timeout of a connection, DefaultTimeout
+	// if zero.
+	Timeout time.Duration
+
+	// ClientOptions are passed to mailbreeze.NewClient for each API key.
+	ClientOptions []mailbreeze.ClientOption
+
+	// ErrorLog logs connection errors. The standard logger is used if nil.
+	ErrorLog *log.Logger
+
+	mu        sync.Mutex
+	clients   map[string]*mailbreeze.Client
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// ListenAndServe listens on s.Addr and serves SMTP connections.
+func (s *Server) ListenAndServe() error {
+	addr := s.Addr
+	if addr == "" {
+		addr = ":2525"
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l until Close is called, and always returns a
+// non-nil error.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.track(l) {
+		_ = l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrack(l)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				time.Sleep(50 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// Close stops the listeners and closes every open connection.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.cancel != nil {
+		s.cancel()
+	}
+	var err error
+	for l := range s.listeners {
+		if closeErr := l.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	return err
+}
+
+func (s *Server) track(l net.Listener) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if s.listeners == nil {
+		s.listeners = make(map[net.Listener]struct{})
+		s.conns = make(map[net.Conn]struct{})
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+	s.listeners[l] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(l net.Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, l)
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// maxCachedClients bounds the API clients kept between connections.
+const maxCachedClients = 1000
+
+// client returns the API client for apiKey. Clients are only cached by
+// rememberClient once the API accepts the key, so keys it rejects do not
+// accumulate.
+func (s *Server) client(apiKey string) *mailbreeze.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.clients[apiKey]; ok {
+		return c
+	}
+	return mailbreeze.NewClient(apiKey, s.ClientOptions...)
+}
+
+// rememberClient caches the client of an API key the API accepted, evicting
+// an arbitrary client when the cache is full.
+func (s *Server) rememberClient(apiKey string, c *mailbreeze.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clients == nil {
+		s.clients = make(map[string]*mailbreeze.Client)
+	}
+	if _, ok := s.clients[apiKey]; !ok && len(s.clients) >= maxCachedClients {
+		for key := range s.clients {
+			delete(s.clients, key)
+			break
+		}
+	}
+	s.clients[apiKey] = c
+}
+
+// forgetClient drops the cached client of an API key the API rejected.
+func (s *Server) forgetClient(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, apiKey)
+}
+
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.ErrorLog != nil {
+		s.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+func (s *Server) hostname() string {
+	if s.Hostname != "" {
+		return s.Hostname
+	}
+	if name, err := os.Hostname(); err == nil {
+		return name
+	}
+	return "localhost"
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = conn.Close()
+		return
+	}
+	s.conns[conn] = struct{}{}
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	sess := &session{server: s, ctx: ctx}
+	sess.setConn(conn)
+	defer func() {
+		// After STARTTLS, sess.conn is the TLS connection
+		s.mu.Lock()
+		delete(s.conns, sess.conn)
+		s.mu.Unlock()
+		_ = sess.conn.Close()
+	}()
+	if err := sess.serve(); err != nil && !errors.Is(err, io.EOF) && !s.isClosed() {
+		s.logf("smtprelay: %s: %v", conn.RemoteAddr(), err)
+	}
+}
+
+// session is the state of one SMTP connection.
+type session struct {
+	server *Server
+	ctx    context.Context
+	conn   net.Conn
+	text   *textproto.Conn
+
+	helo   string
+	tls    bool
+	apiKey string
+	client *mailbreeze.Client
+	from   string
+	rcpts  []string
+}
+
+func (c *session) setConn(conn net.Conn) {
+	c.conn = conn
+	c.text = textproto.NewConn(conn)
+}
+
+func (c *session) timeout() time.Duration {
+	if c.server.Timeout > 0 {
+		return c.server.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (c *session) maxBytes() int64 {
+	if c.server.MaxMessageBytes > 0 {
+		return c.server.MaxMessageBytes
+	}
+	return DefaultMaxMessageBytes
+}
+
+func (c *session) reply(r Reply) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout()))
+	return c.text.PrintfLine("%s", r.String())
+}
+
+func (c *session) replyf(code int, enhanced, format string, args ...interface{}) error {
+	return c.reply(Reply{Code: code, Enhanced: enhanced, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *session) readLine() (string, error) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.timeout()))
+	return c.text.ReadLine()
+}
+
+func (c *session) serve() error {
+	if err := c.replyf(220, "", "%s ESMTP MailBreeze relay", c.server.hostname()); err != nil {
+		return err
+	}
+
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		switch verb = strings.ToUpper(verb); verb {
+		case "HELO", "EHLO":
+			if arg == "" {
+				err = c.replyf(501, "5.5.4", "Syntax: %s hostname", verb)
+				break
+			}
+			c.helo = arg
+			c.reset()
+			if verb == "EHLO" {
+				err = c.ehlo()
+			} else {
+				err = c.replyf(250, "", "%s", c.server.hostname())
+			}
+		case "STARTTLS":
+			err = c.startTLS()
+		case "AUTH":
+			err = c.auth(arg)
+		case "MAIL":
+			err = c.mail(arg)
+		case "RCPT":
+			err = c.rcpt(arg)
+		case "DATA":
+			err = c.data()
+		case "RSET":
+			c.reset()
+			err = c.replyf(250, "2.0.0", "OK")
+		case "NOOP":
+			err = c.replyf(250, "2.0.0", "OK")
+		case "VRFY":
+			err = c.replyf(252, "2.5.0", "Cannot VRFY user")
+		case "QUIT":
+			return c.replyf(221, "2.0.0", "Bye")
+		default:
+			err = c.replyf(500, "5.5.2", "Command not recognized")
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *session) reset() {
+	c.from = ""
+	c.rcpts = nil
+}
+
+func (c *session) canAuth() bool {
+	return c.tls || c.server.AllowInsecureAuth
+}
+
+func (c *session) ehlo() error {
+	lines := []string{
+		c.server.hostname(),
+		"PIPELINING",
+		"8BITMIME",
+		"ENHANCEDSTATUSCODES",
+		"SIZE " + strconv.FormatInt(c.maxBytes(), 10),
+	}
+	if c.server.TLSConfig != nil && !c.tls {
+		lines = append(lines, "STARTTLS")
+	}
+	if c.canAuth() {
+		lines = append(lines, "AUTH PLAIN LOGIN")
+	}
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout()))
+	for i, line := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+		if err := c.text.PrintfLine("250%s%s", sep, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *session) startTLS() error {
+	if c.server.TLSConfig == nil || c.tls {
+		return c.replyf(502, "5.5.1", "STARTTLS not available")
+	}
+	if err := c.replyf(220, "2.0.0", "Ready to start TLS"); err != nil {
+		return err
+	}
+	tlsConn := tls.Server(c.conn, c.server.TLSConfig)
+	_ = tlsConn.SetDeadline(time.Now().Add(c.timeout()))
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+
+	c.server.mu.Lock()
+	delete(c.server.conns, c.conn)
+	c.server.conns[tlsConn] = struct{}{}
+	c.server.mu.Unlock()
+
+	c.setConn(tlsConn)
+	c.tls = true
+	c.helo = ""
+	c.apiKey = ""
+	c.client = nil
+	c.reset()
+	return nil
+}
+
+func (c *session) auth(arg string) error {
+	switch {
+	case c.helo == "":
+		return c.replyf(503, "5.5.1", "Send EHLO first")
+	case !c.canAuth():
+		return c.replyf(538, "5.7.11", "Encryption required for requested authentication mechanism")
+	case c.apiKey != "":
+		return c.replyf(503, "5.5.1", "Already authenticated")
+	}
+
+	mechanism, initial, _ := strings.Cut(arg, " ")
+	var password string
+	switch strings.ToUpper(mechanism) {
+	case "PLAIN":
+		resp, err := c.authResponse(initial, "")
+		if err != nil || resp == nil {
+			return err
+		}
+		// authorization identity, username, password
+		parts := strings.SplitN(string(resp), "\x00", 3)
+		if len(parts) != 3 {
+			return c.replyf(501, "5.5.2", "Malformed AUTH PLAIN response")
+		}
+		password = parts[2]
+	case "LOGIN":
+		if resp, err := c.authResponse(initial, "Username:"); err != nil || resp == nil {
+			return err
+		}
+		resp, err := c.authResponse("", "Password:")
+		if err != nil || resp == nil {
+			return err
+		}
+		password = string(resp)
+	default:
+		return c.replyf(504, "5.5.4", "Unrecognized authentication mechanism")
+	}
+
+	if password == "" {
+		return c.replyf(535, "5.7.8", "Authentication credentials invalid")
+	}
+	return c.checkKey(password)
+}
+
+// checkKey checks an API key with a cheap authenticated request before
+// replying to AUTH. A forbidden reply still proves the key is valid, since
+// the key may only be allowed to send.
+func (c *session) checkKey(apiKey string) error {
+	client := c.server.client(apiKey)
+	_, err := client.Emails.Stats(c.ctx)
+	var apiErr *mailbreeze.Error
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden) {
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			c.server.forgetClient(apiKey)
+			return c.replyf(535, "5.7.8", "Authentication credentials invalid")
+		}
+		c.server.logf("smtprelay: checking API key: %v", err)
+		return c.replyf(454, "4.7.0", "Temporary authentication failure")
+	}
+
+	c.server.rememberClient(apiKey, client)
+	c.apiKey = apiKey
+	c.client = client
+	return c.replyf(235, "2.7.0", "Authentication successful")
+}
+
+// authResponse returns the decoded initial response, or prompts for one with
+// a 334 challenge. It returns nil after replying with an error itself.
+func (c *session) authResponse(initial, prompt string) ([]byte, error) {
+	if initial == "" {
+		if err := c.replyf(334, "", "%s", base64.StdEncoding.EncodeToString([]byte(prompt))); err != nil {
+			return nil, err
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		initial = line
+	}
+	if initial == "*" {
+		return nil, c.replyf(501, "5.0.0", "Authentication cancelled")
+	}
+	if initial == "=" {
+		return []byte{}, nil
+	}
+	resp, err := base64.StdEncoding.DecodeString(initial)
+	if err != nil {
+		return nil, c.replyf(501, "5.5.2", "Invalid base64 in AUTH response")
+	}
+	return resp, nil
+}
+
+func (c *session) mail(arg string) error {
+	switch {
+	case c.helo == "":
+		return c.replyf(503, "5.5.1", "Send EHLO first")
+	case c.apiKey == "":
+		return c.replyf(530, "5.7.0", "Authentication required")
+	case c.from != "":
+		return c.replyf(503, "5.5.1", "Nested MAIL command")
+	}
+
+	addr, params, ok := pathArg(arg, "FROM:")
+	if !ok {
+		return c.replyf(501, "5.5.4", "Syntax: MAIL FROM:<address>")
+	}
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "SIZE") {
+			if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > c.maxBytes() {
+				return c.replyf(552, "5.3.4", "Message size exceeds fixed limit")
+			}
+		}
+	}
+	if addr == "" {
+		addr = "<>"
+	}
+	c.from = addr
+	return c.replyf(250, "2.1.0", "OK")
+}
+
+func (c *session) rcpt(arg string) error {
+	if c.from == "" {
+		return c.replyf(503, "5.5.1", "Send MAIL first")
+	}
+	addr, _, ok := pathArg(arg, "TO:")
+	if !ok || addr == "" {
+		return c.replyf(501, "5.5.4", "Syntax: RCPT TO:<address>")
+	}
+	c.rcpts = append(c.rcpts, addr)
+	return c.replyf(250, "2.1.5", "OK")
+}
+
+func (c *session) data() error {
+	if len(c.rcpts) == 0 {
+		return c.replyf(503, "5.5.1", "Send RCPT first")
+	}
+	if err := c.replyf(354, "", "End data with <CR><LF>.<CR><LF>"); err != nil {
+		return err
+	}
+
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.timeout()))
+	limit := c.maxBytes()
+	dot := c.text.DotReader()
+	body, err := io.ReadAll(io.LimitReader(dot, limit+1))
+	if err != nil {
+		return err
+	}
+	defer c.reset()
+	if int64(len(body)) > limit {
+		// Discard the rest of the message before replying
+		if _, err := io.Copy(io.Discard, dot); err != nil {
+			return err
+		}
+		return c.replyf(552, "5.3.4", "Message size exceeds fixed limit")
+	}
+
+	msg, err := mailbreeze.ParseRawMessage(bytes.NewReader(body))
+	if err != nil {
+		return c.replyf(554, "5.6.0", "Malformed message: %v", err)
+	}
+	from := c.from
+	if from == "<>" {
+		from = ""
+	}
+	msg.SetEnvelope(from, c.rcpts)
+
+	result, err := c.client.Emails.SendRawMessage(c.ctx, msg)
+	if err != nil {
+		var apiErr *mailbreeze.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			// The key was revoked since AUTH
+			c.server.forgetClient(c.apiKey)
+		}
+		return c.reply(ReplyForError(err))
+	}
+	return c.replyf(250, "2.0.0", "OK: queued as %s", result.MessageID)
+}
+
+// pathArg parses "FROM:<address> PARAM=value ..." arguments.
+func pathArg(arg, prefix string) (addr string, params []string, ok bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	rest := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(rest, "<") {
+		return "", nil, false
+	}
+	end := strings.IndexByte(rest, '>')
+	if end < 0 {
+		return "", nil, false
+	}
+	addr = rest[1:end]
+	// Drop a source route such as <@a,@b:user@example.com>
+	if strings.HasPrefix(addr, "@") {
+		if colon := strings.IndexByte(addr, ':'); colon >= 0 {
+			addr = addr[colon+1:]
+		}
+	}
+	return addr, strings.Fields(rest[end+1:]), true
+}
@@ -0,0 +1,497 @@
+package smtprelay
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+// apiServer is a fake MailBreeze API that records sent emails. Keys are
+// checked against the email stats endpoint, which replies with authStatus.
+type apiServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	sent       []mailbreeze.SendEmailParams
+	keys       []string
+	status     int
+	authStatus int
+}
+
+func newAPIServer(t *testing.T) *apiServer {
+	api := &apiServer{status: http.StatusCreated, authStatus: http.StatusOK}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		if r.URL.Path == "/api/v1/emails/stats" {
+			w.WriteHeader(api.authStatus)
+			if api.authStatus >= 400 {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"success": false,
+					"error":   map[string]interface{}{"code": "ERR", "message": "key check failed"},
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": map[string]interface{}{}})
+			return
+		}
+		if r.URL.Path != "/api/v1/emails" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var params mailbreeze.SendEmailParams
+		json.NewDecoder(r.Body).Decode(&params)
+		api.sent = append(api.sent, params)
+		api.keys = append(api.keys, r.Header.Get("X-API-Key"))
+
+		w.WriteHeader(api.status)
+		if api.status >= 400 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "ERR", "message": "to is invalid"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"messageId": "msg_smtp"},
+		})
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func startRelay(t *testing.T, srv *Server) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if srv.ErrorLog == nil {
+		srv.ErrorLog = log.New(io.Discard, "", 0)
+	}
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+	return l.Addr().String()
+}
+
+const testMessage = "From: Jane <jane@example.com>\r\n" +
+	"To: bob@example.com, other@example.com\r\n" +
+	"Subject: Hello\r\n" +
+	"\r\n" +
+	"Hi Bob\r\n"
+
+func TestRelaySend(t *testing.T) {
+	api := newAPIServer(t)
+	addr := startRelay(t, &Server{
+		Hostname:          "relay.test",
+		AllowInsecureAuth: true,
+		ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL)},
+	})
+
+	auth := smtp.PlainAuth("", "apikey", "sk_test_relay", "127.0.0.1")
+	err := smtp.SendMail(addr, auth, "bounce@example.com", []string{"bob@example.com", "hidden@example.com"}, []byte(testMessage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(api.sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(api.sent))
+	}
+	sent := api.sent[0]
+	if api.keys[0] != "sk_test_relay" {
+		t.Errorf("expected AUTH password as API key, got %q", api.keys[0])
+	}
+	if sent.From != "Jane <jane@example.com>" || sent.Subject != "Hello" || sent.Text != "Hi Bob\n" {
+		t.Errorf("unexpected params %+v", sent)
+	}
+	if len(sent.To) != 1 || sent.To[0] != "bob@example.com" {
+		t.Errorf("expected To limited to envelope recipients, got %q", sent.To)
+	}
+	if len(sent.BCC) != 1 || sent.BCC[0] != "hidden@example.com" {
+		t.Errorf("expected envelope-only recipient as BCC, got %q", sent.BCC)
+	}
+}
+
+func TestRelayErrorReplies(t *testing.T) {
+	tests := []struct {
+		status int
+		code   int
+	}{
+		{http.StatusBadRequest, 554},
+		{http.StatusUnauthorized, 554},
+		{http.StatusServiceUnavailable, 451},
+	}
+
+	for _, tt := range tests {
+		api := newAPIServer(t)
+		api.status = tt.status
+		addr := startRelay(t, &Server{
+			AllowInsecureAuth: true,
+			ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL), mailbreeze.WithMaxRetries(0)},
+		})
+
+		auth := smtp.PlainAuth("", "", "sk_test", "127.0.0.1")
+		err := smtp.SendMail(addr, auth, "a@example.com", []string{"bob@example.com"}, []byte(testMessage))
+
+		var smtpErr *textproto.Error
+		if !errors.As(err, &smtpErr) || smtpErr.Code != tt.code {
+			t.Errorf("status %d: expected SMTP %d, got %v", tt.status, tt.code, err)
+		}
+	}
+}
+
+func TestRelayAuthChecksKey(t *testing.T) {
+	tests := []struct {
+		authStatus int
+		code       int
+	}{
+		{http.StatusUnauthorized, 535},
+		{http.StatusServiceUnavailable, 454},
+		{http.StatusForbidden, 0},
+	}
+
+	for _, tt := range tests {
+		api := newAPIServer(t)
+		api.authStatus = tt.authStatus
+		addr := startRelay(t, &Server{
+			AllowInsecureAuth: true,
+			ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL), mailbreeze.WithMaxRetries(0)},
+		})
+
+		c, err := smtp.Dial(addr)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		err = c.Auth(smtp.PlainAuth("", "", "sk_test", "127.0.0.1"))
+		c.Close()
+
+		var smtpErr *textproto.Error
+		switch {
+		case tt.code == 0 && err != nil:
+			t.Errorf("status %d: expected AUTH to succeed, got %v", tt.authStatus, err)
+		case tt.code != 0 && (!errors.As(err, &smtpErr) || smtpErr.Code != tt.code):
+			t.Errorf("status %d: expected SMTP %d, got %v", tt.authStatus, tt.code, err)
+		}
+		if len(api.sent) != 0 {
+			t.Errorf("status %d: expected nothing to be sent", tt.authStatus)
+		}
+	}
+}
+
+func TestRelayRequiresAuth(t *testing.T) {
+	addr := startRelay(t, &Server{})
+
+	c, err := smtp.Dial(addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("AUTH"); ok {
+		t.Error("expected AUTH not to be offered without TLS")
+	}
+	err = c.Mail("a@example.com")
+	var smtpErr *textproto.Error
+	if !errors.As(err, &smtpErr) || smtpErr.Code != 530 {
+		t.Errorf("expected 530, got %v", err)
+	}
+}
+
+func TestRelayAuthLogin(t *testing.T) {
+	api := newAPIServer(t)
+	addr := startRelay(t, &Server{
+		AllowInsecureAuth: true,
+		ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL)},
+	})
+
+	conn, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	expect := func(code int) string {
+		t.Helper()
+		_, msg, err := conn.ReadResponse(code)
+		if err != nil {
+			t.Fatalf("expected %d: %v", code, err)
+		}
+		return msg
+	}
+	expect(220)
+	conn.PrintfLine("EHLO client.test")
+	if msg := expect(250); !strings.Contains(msg, "AUTH PLAIN LOGIN") {
+		t.Errorf("expected AUTH to be offered, got %q", msg)
+	}
+	conn.PrintfLine("AUTH LOGIN")
+	expect(334)
+	conn.PrintfLine("dXNlcg==") // user
+	expect(334)
+	conn.PrintfLine("c2tfdGVzdA==") // sk_test
+	expect(235)
+	conn.PrintfLine("MAIL FROM:<a@example.com> SIZE=100")
+	expect(250)
+	conn.PrintfLine("RSET")
+	expect(250)
+	conn.PrintfLine("MAIL FROM:<a@example.com> SIZE=999999999999")
+	expect(552)
+	conn.PrintfLine("BOGUS")
+	expect(500)
+	conn.PrintfLine("QUIT")
+	expect(221)
+}
+
+func TestRelayMessageTooLarge(t *testing.T) {
+	api := newAPIServer(t)
+	addr := startRelay(t, &Server{
+		AllowInsecureAuth: true,
+		MaxMessageBytes:   64,
+		ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL)},
+	})
+
+	auth := smtp.PlainAuth("", "", "sk_test", "127.0.0.1")
+	msg := testMessage + strings.Repeat("padding\r\n", 20)
+	err := smtp.SendMail(addr, auth, "a@example.com", []string{"bob@example.com"}, []byte(msg))
+
+	var smtpErr *textproto.Error
+	if !errors.As(err, &smtpErr) || smtpErr.Code != 552 {
+		t.Errorf("expected 552, got %v", err)
+	}
+	if len(api.sent) != 0 {
+		t.Error("expected nothing to be sent")
+	}
+}
+
+func TestRelayCachesOnlyAcceptedKeys(t *testing.T) {
+	api := newAPIServer(t)
+	api.authStatus = http.StatusUnauthorized
+	srv := &Server{
+		AllowInsecureAuth: true,
+		ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL), mailbreeze.WithMaxRetries(0)},
+	}
+	addr := startRelay(t, srv)
+
+	cached := func() int {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		return len(srv.clients)
+	}
+
+	auth := smtp.PlainAuth("", "", "sk_bogus", "127.0.0.1")
+	if err := smtp.SendMail(addr, auth, "a@example.com", []string{"bob@example.com"}, []byte(testMessage)); err == nil {
+		t.Fatal("expected rejected key to fail")
+	}
+	if n := cached(); n != 0 {
+		t.Errorf("expected rejected key not to be cached, got %d clients", n)
+	}
+
+	api.mu.Lock()
+	api.authStatus = http.StatusOK
+	api.mu.Unlock()
+
+	auth = smtp.PlainAuth("", "", "sk_good", "127.0.0.1")
+	if err := smtp.SendMail(addr, auth, "a@example.com", []string{"bob@example.com"}, []byte(testMessage)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := cached(); n != 1 {
+		t.Errorf("expected accepted key to be cached, got %d clients", n)
+	}
+}
+
+// testTLSConfig returns a server TLS config with a self-signed certificate
+// for 127.0.0.1.
+func testTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+}
+
+func TestRelayStartTLS(t *testing.T) {
+	api := newAPIServer(t)
+	addr := startRelay(t, &Server{
+		TLSConfig:     testTLSConfig(t),
+		ClientOptions: []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL)},
+	})
+
+	c, err := smtp.Dial(addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("AUTH"); ok {
+		t.Error("expected AUTH not to be offered before STARTTLS")
+	}
+	if err := c.StartTLS(&tls.Config{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("STARTTLS: %v", err)
+	}
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		t.Error("expected STARTTLS not to be offered twice")
+	}
+	if err := c.Auth(smtp.PlainAuth("", "", "sk_test", "127.0.0.1")); err != nil {
+		t.Fatalf("AUTH: %v", err)
+	}
+	if err := c.Mail("a@example.com"); err != nil {
+		t.Fatalf("MAIL: %v", err)
+	}
+	if err := c.Rcpt("bob@example.com"); err != nil {
+		t.Fatalf("RCPT: %v", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		t.Fatalf("DATA: %v", err)
+	}
+	io.WriteString(w, testMessage)
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Quit(); err != nil {
+		t.Errorf("QUIT: %v", err)
+	}
+	if len(api.sent) != 1 {
+		t.Errorf("expected 1 email, got %d", len(api.sent))
+	}
+}
+
+func TestRelayCommandErrors(t *testing.T) {
+	api := newAPIServer(t)
+	addr := startRelay(t, &Server{
+		Hostname:          "relay.test",
+		AllowInsecureAuth: true,
+		ClientOptions:     []mailbreeze.ClientOption{mailbreeze.WithBaseURL(api.URL)},
+	})
+
+	conn, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	steps := []struct {
+		command string
+		code    int
+	}{
+		{"AUTH PLAIN", 503},
+		{"MAIL FROM:<a@example.com>", 503},
+		{"HELO", 501},
+		{"HELO client.test", 250},
+		{"STARTTLS", 502},
+		{"NOOP", 250},
+		{"VRFY bob", 252},
+		{"MAIL FROM:<a@example.com>", 530},
+		{"AUTH CRAM-MD5", 504},
+		{"AUTH PLAIN !!!", 501},
+		{"AUTH PLAIN dXNlcg==", 501}, // user
+		{"AUTH PLAIN =", 501},
+		{"AUTH PLAIN AHVzZXIA", 535}, // \x00user\x00
+		{"AUTH LOGIN *", 501},
+		{"AUTH PLAIN AHVzZXIAc2tfdGVzdA==", 235}, // \x00user\x00sk_test
+		{"AUTH PLAIN AHVzZXIAc2tfdGVzdA==", 503},
+		{"RCPT TO:<bob@example.com>", 503},
+		{"DATA", 503},
+		{"MAIL bob", 501},
+		{"MAIL FROM:<>", 250},
+		{"MAIL FROM:<a@example.com>", 503},
+		{"RCPT TO:<>", 501},
+		{"RCPT TO:<@relay.test:bob@example.com>", 250},
+		{"RSET", 250},
+		{"QUIT", 221},
+	}
+	if _, _, err := conn.ReadResponse(220); err != nil {
+		t.Fatalf("greeting: %v", err)
+	}
+	for _, step := range steps {
+		if err := conn.PrintfLine("%s", step.command); err != nil {
+			t.Fatalf("%s: %v", step.command, err)
+		}
+		if code, msg, err := conn.ReadResponse(step.code); err != nil {
+			t.Errorf("%s: expected %d, got %d %s", step.command, step.code, code, msg)
+		}
+	}
+}
+
+func TestRelayTimeout(t *testing.T) {
+	addr := startRelay(t, &Server{Timeout: 50 * time.Millisecond})
+
+	conn, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	if _, _, err := conn.ReadResponse(220); err != nil {
+		t.Fatalf("greeting: %v", err)
+	}
+	// The idle connection is closed once the read deadline passes
+	if _, err := conn.ReadLine(); err == nil {
+		t.Error("expected idle connection to be closed")
+	}
+}
+
+func TestRelayClose(t *testing.T) {
+	srv := &Server{ErrorLog: log.New(io.Discard, "", 0)}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(l) }()
+
+	conn, err := textproto.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, _, err := conn.ReadResponse(220); err != nil {
+		t.Fatalf("greeting: %v", err)
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := <-done; !errors.Is(err, ErrServerClosed) {
+		t.Errorf("expected ErrServerClosed from Serve, got %v", err)
+	}
+	if _, err := conn.ReadLine(); err == nil {
+		t.Error("expected open connection to be closed")
+	}
+	if err := srv.ListenAndServe(); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("expected ErrServerClosed after Close, got %v", err)
+	}
+}
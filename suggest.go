@@ -0,0 +1,200 @@
+package mailbreeze
+
+import "strings"
+
+// popularDomains are widely used mailbox providers that SuggestCorrection
+// matches misspelled domains against.
+var popularDomains = []string{
+	"163.com", "aol.com", "att.net", "btinternet.com", "comcast.net",
+	"email.com", "gmail.com", "gmx.com", "gmx.de", "gmx.net", "googlemail.com", "hotmail.co.uk",
+	"hotmail.com", "hotmail.fr", "icloud.com", "live.com", "mail.com",
+	"mail.ru", "me.com", "msn.com", "naver.com", "orange.fr",
+	"outlook.com", "proton.me", "protonmail.com", "qq.com", "sbcglobal.net",
+	"verizon.net", "web.de", "yahoo.co.uk", "yahoo.com", "yahoo.fr",
+	"yandex.com", "yandex.ru", "ymail.com", "zoho.com",
+}
+
+// knownDomains are legitimate mailbox providers that are close to a popular
+// domain but must not be reported as misspellings of it.
+var knownDomains = map[string]bool{
+	"aim.com": true, "bigpond.com": true, "free.fr": true, "gmx.at": true,
+	"gmx.ch": true, "gmx.fr": true, "hey.com": true, "hotmail.de": true,
+	"hotmail.es": true, "hotmail.it": true, "inbox.ru": true, "laposte.net": true,
+	"libero.it": true, "live.de": true, "live.fr": true, "mac.com": true,
+	"mail.de": true, "mail.ee": true, "outlook.de": true, "outlook.fr": true,
+	"rocketmail.com": true, "seznam.cz": true, "t-online.de": true,
+	"tutanota.com": true, "wp.pl": true, "yahoo.ca": true, "yahoo.de": true,
+	"yahoo.es": true, "yahoo.in": true, "yahoo.it": true, "yandex.ua": true,
+}
+
+// popularTLDs are top-level domains that SuggestCorrection matches misspelled
+// top-level domains against.
+var popularTLDs = []string{
+	"au", "biz", "ca", "co", "com", "de", "edu", "es", "fr", "gh", "gov",
+	"in", "info", "io", "it", "ke", "me", "net", "ng", "nl", "org", "ru",
+	"uk", "us", "za",
+}
+
+// knownTLDs are real top-level domains, which are never corrected: every
+// country code and the widely used generic top-level domains.
+var knownTLDs = parseTLDList(`
+ac ad ae af ag ai al am ao aq ar as at au aw ax az
+ba bb bd be bf bg bh bi bj bm bn bo bq br bs bt bw by bz
+ca cc cd cf cg ch ci ck cl cm cn co cr cu cv cw cx cy cz
+de dj dk dm do dz ec ee eg er es et eu fi fj fk fm fo fr
+ga gd ge gf gg gh gi gl gm gn gp gq gr gs gt gu gw gy
+hk hm hn hr ht hu id ie il im in io iq ir is it je jm jo jp
+ke kg kh ki km kn kp kr kw ky kz la lb lc li lk lr ls lt lu lv ly
+ma mc md me mg mh mk ml mm mn mo mp mq mr ms mt mu mv mw mx my mz
+na nc ne nf ng ni nl no np nr nu nz om
+pa pe pf pg ph pk pl pm pn pr ps pt pw py qa re ro rs ru rw
+sa sb sc sd se sg sh si sk sl sm sn so sr ss st su sv sx sy sz
+tc td tf tg th tj tk tl tm tn to tr tt tv tw tz
+ua ug uk us uy uz va vc ve vg vi vn vu wf ws ye yt za zm zw
+
+aero app arpa art asia bank biz blog cat club coop com cloud dev edu
+email gov info int jobs live mil mobi museum name net news online org
+page pro shop site store tech tel top travel vip xyz
+`)
+
+func parseTLDList(list string) map[string]bool {
+	tlds := make(map[string]bool)
+	for _, tld := range strings.Fields(list) {
+		tlds[tld] = true
+	}
+	return tlds
+}
+
+// SuggestCorrection returns a corrected address when the domain of email looks
+// like a misspelling of a popular mailbox provider or top-level domain, such as
+// "user@gmial.com" or "user@example.cmo". It returns an empty string when the
+// address is invalid or no correction is found.
+func SuggestCorrection(email string) string {
+	local, domain, ok := parseAddress(strings.TrimSpace(email))
+	if !ok {
+		return ""
+	}
+
+	suggestion := suggestDomain(domain)
+	if suggestion == "" {
+		return ""
+	}
+	return local + "@" + suggestion
+}
+
+// suggestDomain returns the closest popular domain, or a domain with a
+// corrected top-level domain, or an empty string. Known providers are never
+// corrected, and neither is a real top-level domain: "yahoo.de" is not a
+// misspelling of "yahoo.fr".
+func suggestDomain(domain string) string {
+	if knownDomains[domain] {
+		return ""
+	}
+	name, tld := splitDomain(domain)
+
+	best := ""
+	bestDistance := maxSuggestDistance(domain) + 1
+	for _, candidate := range popularDomains {
+		if candidate == domain {
+			return ""
+		}
+		candidateName, candidateTLD := splitDomain(candidate)
+		if knownTLDs[tld] && tld != candidateTLD {
+			// A real TLD is only a typo when it is the provider's TLD cut
+			// short, as in "gmail.co" or "yaho.co", and then the name may
+			// only be misspelled if it is not another provider's name, as
+			// "mail" in "mail.co" is
+			if !isTruncatedTLD(tld, candidateTLD) || len(candidateName) < minTruncatedNameLength {
+				continue
+			}
+			d := editDistance(name, candidateName)
+			if d > 0 && isProviderName(name) {
+				continue
+			}
+			if d <= 1 && d < bestDistance {
+				best, bestDistance = candidate, d
+			}
+			continue
+		}
+		if d := editDistance(domain, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best != "" || tld == "" || knownTLDs[tld] {
+		return best
+	}
+
+	for _, candidate := range popularTLDs {
+		if len(candidate) == len(tld) && editDistance(tld, candidate) == 1 {
+			return name + "." + candidate
+		}
+	}
+	return ""
+}
+
+// minTruncatedNameLength is the shortest provider name matched with a cut
+// short TLD. Shorter names are common words registered under many TLDs, such
+// as "mail.co" and "me.co".
+const minTruncatedNameLength = 5
+
+// splitDomain splits domain into its name and last label.
+func splitDomain(domain string) (name, tld string) {
+	if dot := strings.LastIndexByte(domain, '.'); dot >= 0 {
+		return domain[:dot], domain[dot+1:]
+	}
+	return domain, ""
+}
+
+// isProviderName reports whether name is the name of a popular domain.
+func isProviderName(name string) bool {
+	for _, domain := range popularDomains {
+		if candidateName, _ := splitDomain(domain); candidateName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isTruncatedTLD reports whether tld is candidate with its end cut off.
+func isTruncatedTLD(tld, candidate string) bool {
+	return len(tld) < len(candidate) && strings.HasPrefix(candidate, tld)
+}
+
+// maxSuggestDistance returns the largest edit distance accepted as a typo.
+// Short domains are only one edit apart from many real ones, such as
+// "mac.com" and "mail.com", so they only match at distance 1.
+func maxSuggestDistance(domain string) int {
+	if len(domain) < 10 {
+		return 1
+	}
+	return 2
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and adjacent
+// transpositions needed to turn a into b.
+func editDistance(a, b string) int {
+	rows, cols := len(a)+1, len(b)+1
+	d := make([][]int, rows)
+	for i := range d {
+		d[i] = make([]int, cols)
+		d[i][0] = i
+	}
+	for j := 0; j < cols; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[rows-1][cols-1]
+}
@@ -0,0 +1,185 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSuggestCorrection(t *testing.T) {
+	tests := map[string]string{
+		"user@gmial.com":     "user@gmail.com",
+		"user@gmail.cmo":     "user@gmail.com",
+		"user@yaho.com":      "user@yahoo.com",
+		"user@gmail.co":      "user@gmail.com",
+		"user@hotmal.com":    "user@hotmail.com",
+		"user@outlook.co":    "user@outlook.com",
+		"User@GMAIL.CON":     "User@gmail.com",
+		"user@example.cmo":   "user@example.com",
+		"user@example.nte":   "user@example.net",
+		"user@gmail.com":     "",
+		"user@example.com":   "",
+		"user@example.co":    "",
+		"user@mycompany.ng":  "",
+		"user@localhost":     "",
+		"not-an-email":       "",
+		"user@qq.con":        "user@qq.com",
+		"user@subdomain.abc": "",
+
+		// Real providers and real top-level domains are not typos
+		"user@mac.com":    "",
+		"user@aim.com":    "",
+		"user@hey.com":    "",
+		"user@yahoo.de":   "",
+		"user@mail.de":    "",
+		"user@gmx.ch":     "",
+		"user@qq.cm":      "",
+		"user@company.cm": "",
+		"user@example.se": "",
+		"user@example.dk": "",
+		"user@example.pl": "",
+		"user@mail.co":    "",
+		"user@me.co":      "",
+		"user@live.co":    "",
+		"user@qq.co":      "",
+
+		// A misspelled provider name with its TLD cut short
+		"user@yaho.co":  "user@yahoo.com",
+		"user@gmial.co": "user@gmail.com",
+	}
+
+	for email, expected := range tests {
+		if got := SuggestCorrection(email); got != expected {
+			t.Errorf("SuggestCorrection(%q): expected %q, got %q", email, expected, got)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"gmail", "gmail", 0},
+		{"gmial", "gmail", 1},
+		{"gmal", "gmail", 1},
+		{"gmaill", "gmail", 1},
+		{"gnail", "gmail", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.distance {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.distance, got)
+		}
+	}
+}
+
+func TestPrecheckDidYouMean(t *testing.T) {
+	result := Precheck("jane@gmial.com")
+
+	if result.DidYouMean != "jane@gmail.com" {
+		t.Errorf("expected DidYouMean 'jane@gmail.com', got '%s'", result.DidYouMean)
+	}
+}
+
+func TestVerificationVerifyDidYouMean(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		data := map[string]interface{}{
+			"email":  body["email"],
+			"result": "invalid",
+		}
+		if body["email"] == "user@yhoo.com" {
+			data["didYouMean"] = "user@yahoo.com"
+		}
+		if body["email"] == "user@hotmial.com" {
+			data["result"] = "valid"
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.Verify(context.Background(), &VerifyEmailParams{Email: "user@gmial.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DidYouMean != "user@gmail.com" {
+		t.Errorf("expected local suggestion 'user@gmail.com', got '%s'", result.DidYouMean)
+	}
+
+	result, err = client.Verification.Verify(context.Background(), &VerifyEmailParams{Email: "user@yhoo.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DidYouMean != "user@yahoo.com" {
+		t.Errorf("expected API suggestion 'user@yahoo.com', got '%s'", result.DidYouMean)
+	}
+
+	// Addresses the API verified as deliverable are not second-guessed
+	result, err = client.Verification.Verify(context.Background(), &VerifyEmailParams{Email: "user@hotmial.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DidYouMean != "" {
+		t.Errorf("expected no suggestion for a valid result, got '%s'", result.DidYouMean)
+	}
+}
+
+func TestVerificationGetDidYouMean(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId": "ver_123",
+				"status":         "completed",
+				"results": []map[string]interface{}{
+					{"email": "user@hotmial.com", "result": "invalid"},
+					{"email": "user@example.com", "result": "valid", "isValid": true},
+				},
+				"createdAt": "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.Get(context.Background(), "ver_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	detailed := result.Results.Detailed()
+	if detailed[0].DidYouMean != "user@hotmail.com" {
+		t.Errorf("expected 'user@hotmail.com', got '%s'", detailed[0].DidYouMean)
+	}
+	if detailed[1].DidYouMean != "" {
+		t.Errorf("expected no suggestion, got '%s'", detailed[1].DidYouMean)
+	}
+}
+
+func TestSuggestCorrectionKnownProviders(t *testing.T) {
+	// Every popular and known domain must be left alone
+	domains := append([]string(nil), popularDomains...)
+	for domain := range knownDomains {
+		domains = append(domains, domain)
+	}
+	for _, domain := range domains {
+		if got := SuggestCorrection("user@" + domain); got != "" {
+			t.Errorf("expected no suggestion for %s, got %q", domain, got)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package mailbreeze
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultTemplateSchemaTTL is how long declared template variables are cached
+// when template validation is enabled.
+const DefaultTemplateSchemaTTL = 5 * time.Minute
+
+// TemplateVariableType is the value type of a template variable.
+type TemplateVariableType string
+
+const (
+	TemplateVariableTypeString  TemplateVariableType = "string"
+	TemplateVariableTypeNumber  TemplateVariableType = "number"
+	TemplateVariableTypeBoolean TemplateVariableType = "boolean"
+	TemplateVariableTypeDate    TemplateVariableType = "date"
+	TemplateVariableTypeArray   TemplateVariableType = "array"
+	TemplateVariableTypeObject  TemplateVariableType = "object"
+)
+
+// TemplateVariable is a variable declared by a template.
+type TemplateVariable struct {
+	Name     string               `json:"name"`
+	Type     TemplateVariableType `json:"type,omitempty"`
+	Required bool                 `json:"required"`
+}
+
+// ValidateTemplateVariables checks variables against the variables declared
+// by a template: required variables must be present and non-nil, and values
+// must match the declared type. Variables the template does not declare are
+// allowed. Invalid variables are returned as FieldErrors.
+func ValidateTemplateVariables(declared []TemplateVariable, variables map[string]interface{}) error {
+	var errs FieldErrors
+	for _, variable := range declared {
+		field := "variables." + variable.Name
+		value, ok := variables[variable.Name]
+		if !ok || value == nil {
+			if variable.Required {
+				errs = append(errs, FieldError{Field: field, Message: "required variable is missing"})
+			}
+			continue
+		}
+		if !matchesVariableType(variable.Type, value) {
+			errs = append(errs, FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("expected %s, got %T", variable.Type, value),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// matchesVariableType reports whether value can be used for a variable of type t.
+// Variables without a declared type accept any value.
+func matchesVariableType(t TemplateVariableType, value interface{}) bool {
+	kind := reflect.ValueOf(value).Kind()
+	switch t {
+	case TemplateVariableTypeString:
+		return kind == reflect.String
+	case TemplateVariableTypeNumber:
+		return isNumeric(value)
+	case TemplateVariableTypeBoolean:
+		return kind == reflect.Bool
+	case TemplateVariableTypeDate:
+		switch value.(type) {
+		case time.Time, *time.Time:
+			return true
+		case string:
+			_, err := coerceTime(value)
+			return err == nil
+		}
+		return false
+	case TemplateVariableTypeArray:
+		return kind == reflect.Slice || kind == reflect.Array
+	case TemplateVariableTypeObject:
+		if _, ok := value.(time.Time); ok {
+			return false
+		}
+		return kind == reflect.Map || kind == reflect.Struct ||
+			(kind == reflect.Pointer && reflect.ValueOf(value).Elem().Kind() == reflect.Struct)
+	default:
+		return true
+	}
+}
+
+// isNumeric reports whether value is of an integer or floating-point kind.
+func isNumeric(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// templateSchemaCache caches the declared variables of templates.
+type templateSchemaCache struct {
+	templates *TemplatesResource
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]templateSchemaEntry
+}
+
+type templateSchemaEntry struct {
+	variables []TemplateVariable
+	expires   time.Time
+}
+
+func newTemplateSchemaCache(templates *TemplatesResource, ttl time.Duration) *templateSchemaCache {
+	if ttl <= 0 {
+		ttl = DefaultTemplateSchemaTTL
+	}
+	return &templateSchemaCache{
+		templates: templates,
+		ttl:       ttl,
+		now:       time.Now,
+		entries:   make(map[string]templateSchemaEntry),
+	}
+}
+
+// variables returns the declared variables of a template, fetching the
+// template if it is not cached or its entry has expired.
+func (c *templateSchemaCache) variables(ctx context.Context, templateID string) ([]TemplateVariable, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[templateID]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.variables, nil
+	}
+
+	template, err := c.templates.Get(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[templateID] = templateSchemaEntry{variables: template.Variables, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return template.Variables, nil
+}
+
+// validate checks the variables of a send against its template.
+func (c *templateSchemaCache) validate(ctx context.Context, params *SendEmailParams) error {
+	if params == nil || params.TemplateID == "" {
+		return nil
+	}
+	declared, err := c.variables(ctx, params.TemplateID)
+	if err != nil {
+		return fmt.Errorf("mailbreeze: failed to fetch variables of template %s: %w", params.TemplateID, err)
+	}
+	if len(params.Personalizations) == 0 {
+		return ValidateTemplateVariables(declared, params.Variables)
+	}
+
+	var errs FieldErrors
+	for i, p := range params.Personalizations {
+		err := ValidateTemplateVariables(declared, mergeMaps(params.Variables, p.Variables))
+		if fieldErrs, ok := err.(FieldErrors); ok {
+			for _, fieldErr := range fieldErrs {
+				fieldErr.Field = fmt.Sprintf("personalizations[%d].%s", i, fieldErr.Field)
+				errs = append(errs, fieldErr)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
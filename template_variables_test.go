@@ -0,0 +1,200 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateTemplateVariables(t *testing.T) {
+	declared := []TemplateVariable{
+		{Name: "first_name", Type: TemplateVariableTypeString, Required: true},
+		{Name: "credits", Type: TemplateVariableTypeNumber},
+		{Name: "vip", Type: TemplateVariableTypeBoolean},
+		{Name: "renews_on", Type: TemplateVariableTypeDate},
+		{Name: "items", Type: TemplateVariableTypeArray},
+		{Name: "account", Type: TemplateVariableTypeObject},
+		{Name: "anything"},
+	}
+
+	tests := []struct {
+		name      string
+		variables map[string]interface{}
+		fields    []string
+	}{
+		{
+			name:      "valid",
+			variables: map[string]interface{}{"first_name": "Jane", "credits": 3, "vip": true, "renews_on": "2024-01-01", "items": []string{"a"}, "account": map[string]interface{}{"id": 1}, "anything": 1.5},
+		},
+		{
+			name:      "time value and extra variables",
+			variables: map[string]interface{}{"first_name": "Jane", "renews_on": time.Now(), "unused": "x"},
+		},
+		{
+			name:      "missing required",
+			variables: map[string]interface{}{"credits": 3.5},
+			fields:    []string{"variables.first_name"},
+		},
+		{
+			name:      "nil required",
+			variables: map[string]interface{}{"first_name": nil},
+			fields:    []string{"variables.first_name"},
+		},
+		{
+			name:      "wrong types",
+			variables: map[string]interface{}{"first_name": 42, "credits": "3", "vip": "yes", "renews_on": "soon", "items": "a", "account": time.Now()},
+			fields:    []string{"variables.first_name", "variables.credits", "variables.vip", "variables.renews_on", "variables.items", "variables.account"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTemplateVariables(declared, tt.variables)
+			if tt.fields == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var fieldErrs FieldErrors
+			if !errors.As(err, &fieldErrs) {
+				t.Fatalf("expected FieldErrors, got %v", err)
+			}
+			if len(fieldErrs) != len(tt.fields) {
+				t.Fatalf("expected errors for %v, got %v", tt.fields, err)
+			}
+			for i, field := range tt.fields {
+				if fieldErrs[i].Field != field {
+					t.Errorf("expected error %d for %s, got %s", i, field, fieldErrs[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func newTemplateValidationServer(t *testing.T, templateGets, sends *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/templates/tmpl_123":
+			atomic.AddInt32(templateGets, 1)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"id":   "tmpl_123",
+					"name": "Welcome",
+					"variables": []map[string]interface{}{
+						{"name": "first_name", "type": "string", "required": true},
+					},
+					"createdAt": "2024-01-01T00:00:00Z",
+				},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/emails":
+			atomic.AddInt32(sends, 1)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"messageId": "msg_123"},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestEmailsSendTemplateValidation(t *testing.T) {
+	var templateGets, sends int32
+	server := newTemplateValidationServer(t, &templateGets, &sends)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithTemplateValidation(0))
+
+	params := &SendEmailParams{
+		From:       "hello@example.com",
+		To:         []string{"user@example.com"},
+		TemplateID: "tmpl_123",
+	}
+	_, err := client.Emails.Send(context.Background(), params)
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) || fieldErrs[0].Field != "variables.first_name" {
+		t.Fatalf("expected missing first_name error, got %v", err)
+	}
+	if sends != 0 {
+		t.Error("expected invalid send not to reach the API")
+	}
+
+	params.Variables = map[string]interface{}{"first_name": "Jane"}
+	if _, err := client.Emails.Send(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sends != 1 {
+		t.Errorf("expected 1 send, got %d", sends)
+	}
+	if templateGets != 1 {
+		t.Errorf("expected template to be fetched once, got %d", templateGets)
+	}
+
+	// Sends without a template are not checked
+	_, err = client.Emails.Send(context.Background(), &SendEmailParams{From: "hello@example.com", To: []string{"user@example.com"}, HTML: "<p>Hi</p>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templateGets != 1 {
+		t.Errorf("expected no template fetch, got %d", templateGets)
+	}
+}
+
+func TestEmailsSendTemplateValidationDisabled(t *testing.T) {
+	var templateGets, sends int32
+	server := newTemplateValidationServer(t, &templateGets, &sends)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Emails.Send(context.Background(), &SendEmailParams{
+		From:       "hello@example.com",
+		To:         []string{"user@example.com"},
+		TemplateID: "tmpl_123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templateGets != 0 {
+		t.Errorf("expected no template fetch, got %d", templateGets)
+	}
+}
+
+func TestTemplateSchemaCacheExpiry(t *testing.T) {
+	var templateGets, sends int32
+	server := newTemplateValidationServer(t, &templateGets, &sends)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+	cache := newTemplateSchemaCache(client.Templates, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.variables(context.Background(), "tmpl_123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if templateGets != 1 {
+		t.Errorf("expected 1 fetch, got %d", templateGets)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := cache.variables(context.Background(), "tmpl_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templateGets != 2 {
+		t.Errorf("expected refetch after expiry, got %d", templateGets)
+	}
+}
@@ -0,0 +1,176 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Template is a stored email template. Subject, HTML and Text are the draft
+// content; sends use the published version.
+type Template struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Subject          string     `json:"subject,omitempty"`
+	HTML             string     `json:"html,omitempty"`
+	Text             string     `json:"text,omitempty"`
+	Version          int        `json:"version"`
+	PublishedVersion int        `json:"publishedVersion,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        *time.Time `json:"updatedAt,omitempty"`
+
+	// Variables are the variables the published version declares.
+	Variables []TemplateVariable `json:"variables,omitempty"`
+}
+
+// TemplateVersion is a saved version of a template.
+type TemplateVersion struct {
+	Version     int        `json:"version"`
+	Subject     string     `json:"subject,omitempty"`
+	HTML        string     `json:"html,omitempty"`
+	Text        string     `json:"text,omitempty"`
+	Published   bool       `json:"published"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+}
+
+// CreateTemplateParams are the parameters for creating a template.
+type CreateTemplateParams struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+// UpdateTemplateParams are the parameters for updating a template.
+// Only set fields are changed; use Null to clear a field. Each update saves
+// a new draft version.
+type UpdateTemplateParams struct {
+	Name    Nullable[string] `json:"name"`
+	Subject Nullable[string] `json:"subject"`
+	HTML    Nullable[string] `json:"html"`
+	Text    Nullable[string] `json:"text"`
+}
+
+// MarshalJSON encodes the set fields only.
+func (p UpdateTemplateParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(patchBody(
+		patchField{"name", p.Name},
+		patchField{"subject", p.Subject},
+		patchField{"html", p.HTML},
+		patchField{"text", p.Text},
+	))
+}
+
+// ListTemplatesParams are the parameters for listing templates.
+type ListTemplatesParams struct {
+	Page   int    `json:"page,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Search string `json:"search,omitempty"`
+}
+
+// TemplateList is a paginated list of templates.
+type TemplateList struct {
+	Data       []Template     `json:"data"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+// RenderedTemplate is a template rendered with variables.
+type RenderedTemplate struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// TemplatesResource provides access to template operations.
+type TemplatesResource struct {
+	client *HTTPClient
+}
+
+// Create creates a new template.
+func (r *TemplatesResource) Create(ctx context.Context, params *CreateTemplateParams) (*Template, error) {
+	var template Template
+	if err := r.client.Post(ctx, "/api/v1/templates", params, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Get retrieves a template by ID.
+func (r *TemplatesResource) Get(ctx context.Context, templateID string) (*Template, error) {
+	var template Template
+	if err := r.client.Get(ctx, fmt.Sprintf("/api/v1/templates/%s", templateID), nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// List lists templates.
+func (r *TemplatesResource) List(ctx context.Context, params *ListTemplatesParams) (*TemplateList, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Page > 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Search != "" {
+			query.Set("search", params.Search)
+		}
+	}
+
+	var result TemplateList
+	if err := r.client.Get(ctx, "/api/v1/templates", query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Update partially updates a template. Fields not set in params are unchanged.
+func (r *TemplatesResource) Update(ctx context.Context, templateID string, params *UpdateTemplateParams) (*Template, error) {
+	var template Template
+	if err := r.client.Patch(ctx, fmt.Sprintf("/api/v1/templates/%s", templateID), params, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Delete deletes a template.
+func (r *TemplatesResource) Delete(ctx context.Context, templateID string) error {
+	return r.client.Delete(ctx, fmt.Sprintf("/api/v1/templates/%s", templateID))
+}
+
+// Versions lists the saved versions of a template, newest first.
+func (r *TemplatesResource) Versions(ctx context.Context, templateID string) ([]TemplateVersion, error) {
+	var versions []TemplateVersion
+	if err := r.client.Get(ctx, fmt.Sprintf("/api/v1/templates/%s/versions", templateID), nil, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// Publish makes a version of a template the one used for sends.
+func (r *TemplatesResource) Publish(ctx context.Context, templateID string, version int) (*Template, error) {
+	var template Template
+	path := fmt.Sprintf("/api/v1/templates/%s/versions/%d/publish", templateID, version)
+	if err := r.client.Post(ctx, path, nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Render renders the published version of a template with variables on the
+// server, without sending it, to preview a send.
+func (r *TemplatesResource) Render(ctx context.Context, templateID string, variables map[string]interface{}) (*RenderedTemplate, error) {
+	var rendered RenderedTemplate
+	body := map[string]interface{}{"variables": variables}
+	if err := r.client.Post(ctx, fmt.Sprintf("/api/v1/templates/%s/render", templateID), body, &rendered); err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
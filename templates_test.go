@@ -0,0 +1,222 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func templateJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"id":               "tmpl_123",
+		"name":             "Welcome",
+		"subject":          "Welcome, {{first_name}}",
+		"html":             "<p>Hello {{first_name}}</p>",
+		"version":          3,
+		"publishedVersion": 2,
+		"createdAt":        "2024-01-01T00:00:00Z",
+	}
+}
+
+func TestTemplatesCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/templates" {
+			t.Errorf("expected /api/v1/templates, got %s", r.URL.Path)
+		}
+
+		var body CreateTemplateParams
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Name != "Welcome" || body.HTML == "" {
+			t.Errorf("unexpected body %+v", body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": templateJSON()})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	template, err := client.Templates.Create(context.Background(), &CreateTemplateParams{
+		Name:    "Welcome",
+		Subject: "Welcome, {{first_name}}",
+		HTML:    "<p>Hello {{first_name}}</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.ID != "tmpl_123" || template.PublishedVersion != 2 {
+		t.Errorf("unexpected template %+v", template)
+	}
+}
+
+func TestTemplatesGetAndList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		var data interface{}
+		switch r.URL.Path {
+		case "/api/v1/templates/tmpl_123":
+			data = templateJSON()
+		case "/api/v1/templates":
+			if r.URL.Query().Get("search") != "welcome" || r.URL.Query().Get("page") != "2" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			data = map[string]interface{}{
+				"data":       []interface{}{templateJSON()},
+				"pagination": map[string]interface{}{"page": 2, "total": 1},
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	template, err := client.Templates.Get(context.Background(), "tmpl_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.Name != "Welcome" {
+		t.Errorf("expected name 'Welcome', got '%s'", template.Name)
+	}
+
+	list, err := client.Templates.List(context.Background(), &ListTemplatesParams{Page: 2, Search: "welcome"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Data) != 1 || list.Pagination.Page != 2 {
+		t.Errorf("unexpected list %+v", list)
+	}
+}
+
+func TestTemplatesUpdateAndDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/templates/tmpl_123" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		switch r.Method {
+		case http.MethodPatch:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body) != 2 || body["subject"] != "Hi" || body["text"] != nil {
+				t.Errorf("unexpected body %v", body)
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": templateJSON()})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Templates.Update(context.Background(), "tmpl_123", &UpdateTemplateParams{
+		Subject: Value("Hi"),
+		Text:    Null[string](),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Templates.Delete(context.Background(), "tmpl_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTemplatesVersionsAndPublish(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/templates/tmpl_123/versions":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": []map[string]interface{}{
+					{"version": 3, "published": false, "createdAt": "2024-01-03T00:00:00Z"},
+					{"version": 2, "published": true, "createdAt": "2024-01-02T00:00:00Z", "publishedAt": "2024-01-02T01:00:00Z"},
+				},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/templates/tmpl_123/versions/3/publish":
+			data := templateJSON()
+			data["publishedVersion"] = 3
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	versions, err := client.Templates.Versions(context.Background(), "tmpl_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 3 || !versions[1].Published || versions[1].PublishedAt == nil {
+		t.Errorf("unexpected versions %+v", versions)
+	}
+
+	template, err := client.Templates.Publish(context.Background(), "tmpl_123", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.PublishedVersion != 3 {
+		t.Errorf("expected published version 3, got %d", template.PublishedVersion)
+	}
+}
+
+func TestTemplatesRender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/templates/tmpl_123/render" {
+			t.Errorf("expected /api/v1/templates/tmpl_123/render, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["first_name"] != "Jane" {
+			t.Errorf("expected first_name Jane, got %v", body.Variables)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"subject": "Welcome, Jane",
+				"html":    "<p>Hello Jane</p>",
+				"text":    "Hello Jane",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	rendered, err := client.Templates.Render(context.Background(), "tmpl_123", map[string]interface{}{"first_name": "Jane"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rendered.Subject != "Welcome, Jane" || rendered.HTML != "<p>Hello Jane</p>" || rendered.Text != "Hello Jane" {
+		t.Errorf("unexpected render %+v", rendered)
+	}
+}
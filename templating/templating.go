@@ -0,0 +1,320 @@
+// Package templating renders emails from Go templates kept in a file system,
+// such as an embed.FS in the application's repository.
+//
+// Each email is a set of files sharing a name, any of which may be missing:
+//
+//	welcome.subject.tmpl  subject line (text/template)
+//	welcome.html.tmpl     HTML body (html/template)
+//	welcome.txt.tmpl      plain-text body (text/template)
+//
+// When an email has an HTML body but no text template, the text body is
+// generated from the rendered HTML.
+//
+// Files under layouts/ are layouts and files under partials/ are partials,
+// for both HTML and text. A partial named partials/button.html.tmpl is
+// available to HTML templates as {{template "button" .}}. A layout renders
+// the email body with {{template "content" .}}:
+//
+//	<!-- layouts/base.html.tmpl -->
+//	<html><body>{{template "content" .}}</body></html>
+//
+//	renderer, err := templating.New(templatesFS, &templating.Options{Layout: "base"})
+//	params, err := renderer.Build("welcome", data, mailbreeze.SendEmailParams{
+//		From: "hello@yourdomain.com",
+//		To:   []string{"user@example.com"},
+//	})
+//	result, err := client.Emails.Send(ctx, params)
+package templating
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/MailBreeze/mailbreeze-go"
+	"github.com/MailBreeze/mailbreeze-go/internal/markup"
+)
+
+const (
+	layoutsDir  = "layouts"
+	partialsDir = "partials"
+
+	subjectExt = ".subject.tmpl"
+	htmlExt    = ".html.tmpl"
+	textExt    = ".txt.tmpl"
+
+	// contentTemplate is the name layouts use to render the email body.
+	contentTemplate = "content"
+)
+
+// Options configures a Renderer.
+type Options struct {
+	// Layout is the name of the layout that wraps every HTML and text body,
+	// such as "base" for layouts/base.html.tmpl. Bodies are not wrapped when
+	// empty, or when the layout has no file for the body's format.
+	Layout string
+
+	// Funcs are added to every template, including layouts and partials.
+	Funcs map[string]interface{}
+}
+
+// Message is a rendered email.
+type Message struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Renderer renders emails from a file system of templates. It is safe for
+// concurrent use.
+type Renderer struct {
+	emails map[string]*email
+}
+
+// email holds the parsed templates of one email.
+type email struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+
+	// htmlRoot and textRoot are the templates executed to render the bodies:
+	// the layout if there is one, or the content template.
+	htmlRoot string
+	textRoot string
+}
+
+// New parses every template in fsys. It returns an error if a template does
+// not parse or the layout in opts does not exist.
+func New(fsys fs.FS, opts *Options) (*Renderer, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	files, err := readTemplates(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	htmlBase := htmltemplate.New("").Funcs(opts.Funcs)
+	textBase := texttemplate.New("").Funcs(opts.Funcs)
+	var htmlLayout, textLayout string
+
+	for _, file := range files {
+		dir, name, ext := splitTemplatePath(file.path)
+		switch dir {
+		case partialsDir:
+			err = parseInto(htmlBase, textBase, ext, name, file.content)
+		case layoutsDir:
+			err = parseInto(htmlBase, textBase, ext, layoutsDir+"/"+name, file.content)
+			if name == opts.Layout && ext == htmlExt {
+				htmlLayout = layoutsDir + "/" + name
+			}
+			if name == opts.Layout && ext == textExt {
+				textLayout = layoutsDir + "/" + name
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("templating: %s: %w", file.path, err)
+		}
+	}
+	if opts.Layout != "" && htmlLayout == "" && textLayout == "" {
+		return nil, fmt.Errorf("templating: layout %q not found", opts.Layout)
+	}
+
+	r := &Renderer{emails: make(map[string]*email)}
+	for _, file := range files {
+		dir, name, ext := splitTemplatePath(file.path)
+		if dir == partialsDir || dir == layoutsDir {
+			continue
+		}
+		if dir != "" {
+			name = dir + "/" + name
+		}
+
+		e := r.emails[name]
+		if e == nil {
+			e = &email{}
+			r.emails[name] = e
+		}
+
+		switch ext {
+		case subjectExt:
+			e.subject, err = texttemplate.New(name).Funcs(opts.Funcs).Parse(file.content)
+		case htmlExt:
+			e.html, err = cloneHTML(htmlBase, file.content)
+			e.htmlRoot = rootTemplate(htmlLayout)
+		case textExt:
+			e.text, err = cloneText(textBase, file.content)
+			e.textRoot = rootTemplate(textLayout)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("templating: %s: %w", file.path, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Names returns the names of the emails that can be rendered, sorted.
+func (r *Renderer) Names() []string {
+	names := make([]string, 0, len(r.emails))
+	for name := range r.emails {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render renders the email called name with data.
+func (r *Renderer) Render(name string, data interface{}) (*Message, error) {
+	e, ok := r.emails[name]
+	if !ok {
+		return nil, fmt.Errorf("templating: email %q not found", name)
+	}
+
+	msg := &Message{}
+	var buf bytes.Buffer
+
+	if e.subject != nil {
+		if err := e.subject.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("templating: render %s subject: %w", name, err)
+		}
+		msg.Subject = strings.Join(strings.Fields(buf.String()), " ")
+		buf.Reset()
+	}
+
+	if e.html != nil {
+		if err := e.html.ExecuteTemplate(&buf, e.htmlRoot, data); err != nil {
+			return nil, fmt.Errorf("templating: render %s HTML: %w", name, err)
+		}
+		msg.HTML = buf.String()
+		buf.Reset()
+	}
+
+	if e.text != nil {
+		if err := e.text.ExecuteTemplate(&buf, e.textRoot, data); err != nil {
+			return nil, fmt.Errorf("templating: render %s text: %w", name, err)
+		}
+		msg.Text = strings.TrimSpace(buf.String())
+	} else if msg.HTML != "" {
+		msg.Text = HTMLToText(msg.HTML)
+	}
+
+	return msg, nil
+}
+
+// Build renders the email called name with data and returns a copy of params
+// with its Subject, HTML and Text set. A subject already set in params is
+// kept when the email has no subject template.
+func (r *Renderer) Build(name string, data interface{}, params mailbreeze.SendEmailParams) (*mailbreeze.SendEmailParams, error) {
+	msg, err := r.Render(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Subject != "" {
+		params.Subject = msg.Subject
+	}
+	params.HTML = msg.HTML
+	params.Text = msg.Text
+	return &params, nil
+}
+
+// HTMLToText converts an HTML body to readable plain text, as used for
+// emails without a text template.
+func HTMLToText(html string) string {
+	return markup.ToText(html)
+}
+
+type templateFile struct {
+	path    string
+	content string
+}
+
+// readTemplates returns every template file in fsys, in lexical order.
+func readTemplates(fsys fs.FS) ([]templateFile, error) {
+	var files []templateFile
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || templateExt(p) == "" {
+			return nil
+		}
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, templateFile{path: p, content: string(content)})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("templating: %w", err)
+	}
+	return files, nil
+}
+
+// templateExt returns the template extension of p, or "" if p is not a template.
+func templateExt(p string) string {
+	for _, ext := range []string{subjectExt, htmlExt, textExt} {
+		if strings.HasSuffix(p, ext) && len(p) > len(ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// splitTemplatePath splits a template path into its directory, name and
+// extension, such as "emails", "welcome" and ".html.tmpl".
+func splitTemplatePath(p string) (dir, name, ext string) {
+	ext = templateExt(p)
+	dir, file := path.Split(strings.TrimSuffix(p, ext))
+	return strings.TrimSuffix(dir, "/"), file, ext
+}
+
+// parseInto adds a layout or partial to the base template set for its format.
+func parseInto(htmlBase *htmltemplate.Template, textBase *texttemplate.Template, ext, name, content string) error {
+	var err error
+	switch ext {
+	case htmlExt:
+		_, err = htmlBase.New(name).Parse(content)
+	case textExt:
+		_, err = textBase.New(name).Parse(content)
+	}
+	return err
+}
+
+func cloneHTML(base *htmltemplate.Template, content string) (*htmltemplate.Template, error) {
+	t, err := base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.New(contentTemplate).Parse(content); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func cloneText(base *texttemplate.Template, content string) (*texttemplate.Template, error) {
+	t, err := base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.New(contentTemplate).Parse(content); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// rootTemplate returns the template to execute for a body.
+func rootTemplate(layout string) string {
+	if layout != "" {
+		return layout
+	}
+	return contentTemplate
+}
@@ -0,0 +1,168 @@
+package templating
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MailBreeze/mailbreeze-go"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/base.html.tmpl":    {Data: []byte(`<html><body>{{template "content" .}}<p>{{template "footer" .}}</p></body></html>`)},
+		"layouts/base.txt.tmpl":     {Data: []byte("{{template \"content\" .}}\n--\nAcme")},
+		"partials/footer.html.tmpl": {Data: []byte(`Sent by {{.Company}}`)},
+		"partials/button.html.tmpl": {Data: []byte(`<a href="{{.URL}}">{{.Label}}</a>`)},
+
+		"welcome.subject.tmpl": {Data: []byte("Welcome,\n {{.Name}}!")},
+		"welcome.html.tmpl":    {Data: []byte(`<h1>Hi {{.Name}}</h1>{{template "button" .Button}}`)},
+		"welcome.txt.tmpl":     {Data: []byte(`Hi {{.Name}}, visit {{.Button.URL}}`)},
+
+		"receipts/paid.subject.tmpl": {Data: []byte(`Receipt {{.Number}}`)},
+		"receipts/paid.html.tmpl":    {Data: []byte(`<p>Total: <b>{{.Total}}</b></p><ul>{{range .Items}}<li>{{.}}</li>{{end}}</ul>`)},
+
+		"README.md": {Data: []byte("not a template")},
+	}
+}
+
+type welcomeData struct {
+	Name    string
+	Company string
+	Button  struct{ URL, Label string }
+}
+
+func newWelcomeData() welcomeData {
+	data := welcomeData{Name: "<Jane>", Company: "Acme"}
+	data.Button.URL = "https://example.com/start"
+	data.Button.Label = "Get started"
+	return data
+}
+
+func TestRenderWithLayout(t *testing.T) {
+	renderer, err := New(testFS(), &Options{Layout: "base"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg, err := renderer.Render("welcome", newWelcomeData())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if msg.Subject != "Welcome, <Jane>!" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	wantHTML := `<html><body><h1>Hi &lt;Jane&gt;</h1><a href="https://example.com/start">Get started</a><p>Sent by Acme</p></body></html>`
+	if msg.HTML != wantHTML {
+		t.Errorf("expected HTML %s, got %s", wantHTML, msg.HTML)
+	}
+	wantText := "Hi <Jane>, visit https://example.com/start\n--\nAcme"
+	if msg.Text != wantText {
+		t.Errorf("expected text %q, got %q", wantText, msg.Text)
+	}
+}
+
+func TestRenderGeneratesText(t *testing.T) {
+	renderer, err := New(testFS(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg, err := renderer.Render("receipts/paid", map[string]interface{}{
+		"Number": 42,
+		"Total":  "$10",
+		"Items":  []string{"Widget", "Gadget"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(msg.HTML, "<p>Total: <b>$10</b></p>") {
+		t.Errorf("expected HTML without layout, got %s", msg.HTML)
+	}
+	want := "Total: $10\n\n- Widget\n- Gadget"
+	if msg.Text != want {
+		t.Errorf("expected text %q, got %q", want, msg.Text)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	renderer, err := New(testFS(), &Options{Layout: "base"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	base := mailbreeze.SendEmailParams{
+		From:    "hello@example.com",
+		To:      []string{"jane@example.com"},
+		Subject: "fallback",
+		Tags:    []string{"welcome"},
+	}
+	params, err := renderer.Build("welcome", newWelcomeData(), base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if params.From != base.From || params.To[0] != "jane@example.com" || params.Tags[0] != "welcome" {
+		t.Errorf("expected base params to be kept, got %+v", params)
+	}
+	if params.Subject != "Welcome, <Jane>!" || params.HTML == "" || params.Text == "" {
+		t.Errorf("expected rendered content, got %+v", params)
+	}
+	if base.HTML != "" {
+		t.Error("expected base params not to be modified")
+	}
+}
+
+func TestNames(t *testing.T) {
+	renderer, err := New(testFS(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := renderer.Names()
+	if len(names) != 2 || names[0] != "receipts/paid" || names[1] != "welcome" {
+		t.Errorf("unexpected names %v", names)
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"hello.html.tmpl": {Data: []byte(`<p>{{upper .}}</p>`)},
+	}
+	renderer, err := New(fsys, &Options{Funcs: map[string]interface{}{"upper": strings.ToUpper}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg, err := renderer.Render("hello", "jane")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.HTML != "<p>JANE</p>" || msg.Text != "JANE" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := New(testFS(), &Options{Layout: "missing"}); err == nil {
+		t.Error("expected error for missing layout")
+	}
+
+	broken := fstest.MapFS{"bad.html.tmpl": {Data: []byte(`{{.Name`)}}
+	if _, err := New(broken, nil); err == nil || !strings.Contains(err.Error(), "bad.html.tmpl") {
+		t.Errorf("expected parse error naming the file, got %v", err)
+	}
+
+	renderer, err := New(testFS(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := renderer.Render("missing", nil); err == nil {
+		t.Error("expected error for missing email")
+	}
+	if _, err := renderer.Render("welcome", 42); err == nil {
+		t.Error("expected execution error")
+	}
+}
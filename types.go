@@ -1,6 +1,10 @@
 package mailbreeze
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // PaginationMeta contains pagination information.
 type PaginationMeta struct {
@@ -47,23 +51,52 @@ type Email struct {
 type SendEmailResult struct {
 	// MessageID is the unique message identifier returned by the API
 	MessageID string `json:"messageId"`
+
+	// Recipients holds the message ID sent to each recipient of a send with
+	// Personalizations. MessageID is then the ID of the first message.
+	Recipients []RecipientMessage `json:"recipients,omitempty"`
+}
+
+// RecipientMessage is the message sent to one recipient of a personalized send.
+type RecipientMessage struct {
+	Email     string `json:"email"`
+	MessageID string `json:"messageId"`
 }
 
 // SendEmailParams are the parameters for sending an email.
 type SendEmailParams struct {
-	From          string            `json:"from"`
-	To            []string          `json:"to"`
-	Subject       string            `json:"subject,omitempty"`
-	HTML          string            `json:"html,omitempty"`
-	Text          string            `json:"text,omitempty"`
-	TemplateID    string            `json:"templateId,omitempty"`
-	Variables     map[string]any    `json:"variables,omitempty"`
-	AttachmentIDs []string          `json:"attachmentIds,omitempty"`
-	ReplyTo       string            `json:"replyTo,omitempty"`
-	CC            []string          `json:"cc,omitempty"`
-	BCC           []string          `json:"bcc,omitempty"`
-	Headers       map[string]string `json:"headers,omitempty"`
-	Tags          []string          `json:"tags,omitempty"`
+	From          string                 `json:"from"`
+	To            []string               `json:"to"`
+	Subject       string                 `json:"subject,omitempty"`
+	HTML          string                 `json:"html,omitempty"`
+	Text          string                 `json:"text,omitempty"`
+	TemplateID    string                 `json:"templateId,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	AttachmentIDs []string               `json:"attachmentIds,omitempty"`
+	ReplyTo       string                 `json:"replyTo,omitempty"`
+	CC            []string               `json:"cc,omitempty"`
+	BCC           []string               `json:"bcc,omitempty"`
+	Headers       map[string]string      `json:"headers,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+
+	// Personalizations send a separate message to each group of recipients,
+	// with its own variables and headers merged over Variables and Headers.
+	// To, CC and BCC must be empty when Personalizations are set.
+	Personalizations []Personalization `json:"personalizations,omitempty"`
+
+	// Markdown is a CommonMark body that is converted to HTML and Text
+	// before sending. See RenderMarkdown.
+	Markdown string `json:"-"`
+}
+
+// Personalization is the recipients, variables and headers of one message of
+// a personalized send.
+type Personalization struct {
+	To        []string               `json:"to"`
+	CC        []string               `json:"cc,omitempty"`
+	BCC       []string               `json:"bcc,omitempty"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	Headers   map[string]string      `json:"headers,omitempty"`
 }
 
 // ListEmailsParams are the parameters for listing emails.
@@ -144,6 +177,7 @@ type CreateContactParams struct {
 	PhoneNumber      string                 `json:"phoneNumber,omitempty"`
 	CustomFields     map[string]interface{} `json:"customFields,omitempty"`
 	Source           string                 `json:"source,omitempty"`
+	SubscribedAt     *time.Time             `json:"subscribedAt,omitempty"`
 	ConsentType      ConsentType            `json:"consentType,omitempty"`
 	ConsentSource    string                 `json:"consentSource,omitempty"`
 	ConsentTimestamp *time.Time             `json:"consentTimestamp,omitempty"`
@@ -151,16 +185,36 @@ type CreateContactParams struct {
 }
 
 // UpdateContactParams are the parameters for updating a contact.
+// Only set fields are changed; use Null to clear a field. Custom fields
+// are merged into the existing ones, and a nil value removes a custom field.
 type UpdateContactParams struct {
-	Email            string                 `json:"email,omitempty"`
-	FirstName        string                 `json:"firstName,omitempty"`
-	LastName         string                 `json:"lastName,omitempty"`
-	PhoneNumber      string                 `json:"phoneNumber,omitempty"`
+	Email            Nullable[string]       `json:"email"`
+	FirstName        Nullable[string]       `json:"firstName"`
+	LastName         Nullable[string]       `json:"lastName"`
+	PhoneNumber      Nullable[string]       `json:"phoneNumber"`
 	CustomFields     map[string]interface{} `json:"customFields,omitempty"`
-	ConsentType      ConsentType            `json:"consentType,omitempty"`
-	ConsentSource    string                 `json:"consentSource,omitempty"`
-	ConsentTimestamp *time.Time             `json:"consentTimestamp,omitempty"`
-	ConsentIpAddress string                 `json:"consentIpAddress,omitempty"`
+	ConsentType      Nullable[ConsentType]  `json:"consentType"`
+	ConsentSource    Nullable[string]       `json:"consentSource"`
+	ConsentTimestamp Nullable[time.Time]    `json:"consentTimestamp"`
+	ConsentIpAddress Nullable[string]       `json:"consentIpAddress"`
+}
+
+// MarshalJSON encodes the set fields only.
+func (p UpdateContactParams) MarshalJSON() ([]byte, error) {
+	body := patchBody(
+		patchField{"email", p.Email},
+		patchField{"firstName", p.FirstName},
+		patchField{"lastName", p.LastName},
+		patchField{"phoneNumber", p.PhoneNumber},
+		patchField{"consentType", p.ConsentType},
+		patchField{"consentSource", p.ConsentSource},
+		patchField{"consentTimestamp", p.ConsentTimestamp},
+		patchField{"consentIpAddress", p.ConsentIpAddress},
+	)
+	if len(p.CustomFields) > 0 {
+		body["customFields"] = p.CustomFields
+	}
+	return json.Marshal(body)
 }
 
 // ListContactsParams are the parameters for listing contacts.
@@ -194,9 +248,18 @@ type CreateListParams struct {
 }
 
 // UpdateListParams are the parameters for updating a list.
+// Only set fields are changed; use Null to clear a field.
 type UpdateListParams struct {
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	Name        Nullable[string] `json:"name"`
+	Description Nullable[string] `json:"description"`
+}
+
+// MarshalJSON encodes the set fields only.
+func (p UpdateListParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(patchBody(
+		patchField{"name", p.Name},
+		patchField{"description", p.Description},
+	))
 }
 
 // ListListsParams are the parameters for listing lists.
@@ -250,6 +313,9 @@ type VerificationResult struct {
 	Cached    bool                 `json:"cached,omitempty"`
 	RiskScore int                  `json:"riskScore,omitempty"`
 	Details   *VerificationDetails `json:"details,omitempty"`
+
+	// DidYouMean is a suggested correction when the address looks misspelled.
+	DidYouMean string `json:"didYouMean,omitempty"`
 }
 
 // BatchVerificationAnalytics contains analytics summary for batch verification.
@@ -268,18 +334,129 @@ type BatchResults struct {
 	Unknown []string `json:"unknown,omitempty"`
 }
 
+// BatchVerificationResults holds the results of a batch verification.
+// The API returns either a list of detailed results or results grouped by
+// category, this type decodes both shapes.
+type BatchVerificationResults struct {
+	detailed []VerificationResult
+	grouped  *BatchResults
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *BatchVerificationResults) UnmarshalJSON(data []byte) error {
+	r.detailed = nil
+	r.grouped = nil
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	// Try to unmarshal as array first
+	var detailed []VerificationResult
+	if err := json.Unmarshal(data, &detailed); err == nil {
+		r.detailed = detailed
+		return nil
+	}
+
+	// Otherwise unmarshal as grouped object
+	var grouped BatchResults
+	if err := json.Unmarshal(data, &grouped); err != nil {
+		return fmt.Errorf("failed to unmarshal batch verification results: %w", err)
+	}
+	r.grouped = &grouped
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, preserving the shape that was decoded.
+func (r BatchVerificationResults) MarshalJSON() ([]byte, error) {
+	if r.grouped != nil {
+		return json.Marshal(r.grouped)
+	}
+	if r.detailed == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(r.detailed)
+}
+
+// Detailed returns the per-email results, or nil if the API returned grouped results.
+func (r *BatchVerificationResults) Detailed() []VerificationResult {
+	if r == nil {
+		return nil
+	}
+	return r.detailed
+}
+
+// Grouped returns the grouped results, or nil if the API returned detailed results.
+func (r *BatchVerificationResults) Grouped() *BatchResults {
+	if r == nil {
+		return nil
+	}
+	return r.grouped
+}
+
+// ByEmail returns the verification status of every email regardless of the
+// shape returned by the API. Grouped results map clean to valid, dirty to
+// invalid and unknown to unknown.
+func (r *BatchVerificationResults) ByEmail() map[string]VerificationStatus {
+	statuses := make(map[string]VerificationStatus)
+	if r == nil {
+		return statuses
+	}
+
+	for _, result := range r.detailed {
+		statuses[result.Email] = result.Result
+	}
+
+	if r.grouped != nil {
+		for _, email := range r.grouped.Clean {
+			statuses[email] = VerificationStatusValid
+		}
+		for _, email := range r.grouped.Dirty {
+			statuses[email] = VerificationStatusInvalid
+		}
+		for _, email := range r.grouped.Unknown {
+			statuses[email] = VerificationStatusUnknown
+		}
+	}
+
+	return statuses
+}
+
+// BatchVerificationStatus represents the processing status of a batch verification.
+type BatchVerificationStatus string
+
+const (
+	BatchVerificationStatusPending    BatchVerificationStatus = "pending"
+	BatchVerificationStatusProcessing BatchVerificationStatus = "processing"
+	BatchVerificationStatusCompleted  BatchVerificationStatus = "completed"
+	BatchVerificationStatusFailed     BatchVerificationStatus = "failed"
+	BatchVerificationStatusCancelled  BatchVerificationStatus = "cancelled"
+)
+
+// IsTerminal returns true if the batch verification will not progress further.
+// Statuses this SDK does not know are treated as terminal, so callers waiting
+// on a verification do not poll forever.
+func (s BatchVerificationStatus) IsTerminal() bool {
+	return s != BatchVerificationStatusPending && s != BatchVerificationStatusProcessing
+}
+
 // BatchVerificationResult is the result of a batch verification.
 type BatchVerificationResult struct {
-	VerificationID  string `json:"verificationId"`
-	Status          string `json:"status"`
-	TotalEmails     int    `json:"totalEmails"`
-	ProcessedEmails int    `json:"processedEmails"`
-	CreditsDeducted int    `json:"creditsDeducted"`
-	// Results can be either []VerificationResult or BatchResults depending on API response
-	Results     interface{}                 `json:"results,omitempty"`
-	Analytics   *BatchVerificationAnalytics `json:"analytics,omitempty"`
-	CreatedAt   time.Time                   `json:"createdAt"`
-	CompletedAt *time.Time                  `json:"completedAt,omitempty"`
+	VerificationID  string                      `json:"verificationId"`
+	Status          BatchVerificationStatus     `json:"status"`
+	TotalEmails     int                         `json:"totalEmails"`
+	ProcessedEmails int                         `json:"processedEmails"`
+	CreditsDeducted int                         `json:"creditsDeducted"`
+	Results         *BatchVerificationResults   `json:"results,omitempty"`
+	Analytics       *BatchVerificationAnalytics `json:"analytics,omitempty"`
+	CreatedAt       time.Time                   `json:"createdAt"`
+	CompletedAt     *time.Time                  `json:"completedAt,omitempty"`
+
+	// PrecheckFailures are the addresses skipped by WithPrecheck. They are not sent to the API.
+	PrecheckFailures []VerificationResult `json:"-"`
+
+	// CachedResults are the addresses answered from the verification cache. They are not sent to the API.
+	CachedResults []VerificationResult `json:"-"`
 }
 
 // VerificationStats contains verification statistics.
@@ -299,9 +476,9 @@ type VerifyEmailParams struct {
 
 // ListVerificationsParams are the parameters for listing batch verifications.
 type ListVerificationsParams struct {
-	Page   int    `json:"page,omitempty"`
-	Limit  int    `json:"limit,omitempty"`
-	Status string `json:"status,omitempty"`
+	Page   int                     `json:"page,omitempty"`
+	Limit  int                     `json:"limit,omitempty"`
+	Status BatchVerificationStatus `json:"status,omitempty"`
 }
 
 // VerificationsResponse is a paginated list of batch verifications.
@@ -324,6 +501,10 @@ type CreateUploadParams struct {
 	ContentType string `json:"contentType"`
 	Size        int64  `json:"size"`
 	Inline      bool   `json:"inline,omitempty"`
+
+	// ContentID is the Content-ID an inline attachment is referenced by
+	// in the HTML body, as in <img src="cid:logo">.
+	ContentID string `json:"contentId,omitempty"`
 }
 
 // Attachment represents an attachment.
@@ -0,0 +1,241 @@
+package mailbreeze
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultUnsubscribeTTL is how long unsubscribe tokens are valid when
+// UnsubscribeOptions.TTL is zero.
+const DefaultUnsubscribeTTL = 90 * 24 * time.Hour
+
+var (
+	// ErrInvalidUnsubscribeToken is returned for tokens that are malformed
+	// or were not signed with the Unsubscriber's secret.
+	ErrInvalidUnsubscribeToken = errors.New("mailbreeze: invalid unsubscribe token")
+
+	// ErrUnsubscribeTokenExpired is returned for correctly signed tokens
+	// past their expiry.
+	ErrUnsubscribeTokenExpired = errors.New("mailbreeze: unsubscribe token expired")
+)
+
+// UnsubscribeOptions configures an Unsubscriber.
+type UnsubscribeOptions struct {
+	// TTL is how long tokens are valid, DefaultUnsubscribeTTL if zero.
+	// Mailbox providers may send the unsubscribe request long after the
+	// message was delivered.
+	TTL time.Duration
+
+	// Mailto is an optional address added to List-Unsubscribe as a mailto:
+	// alternative for clients that do not support one-click unsubscribe.
+	Mailto string
+}
+
+// Unsubscriber implements RFC 8058 one-click unsubscribe. It signs tokens
+// identifying a contact of a list, adds List-Unsubscribe headers linking to
+// its handler, and serves the handler, which suppresses the contact with
+// SuppressReasonUnsubscribed.
+//
+//	unsub, err := mailbreeze.NewUnsubscriber(client, secret, "https://example.com/unsubscribe", nil)
+//	http.Handle("/unsubscribe", unsub)
+//	unsub.Send(ctx, params, listID, contactID)
+type Unsubscriber struct {
+	client *Client
+	secret []byte
+	url    *url.URL
+	ttl    time.Duration
+	mailto string
+	now    func() time.Time
+}
+
+// NewUnsubscriber creates an Unsubscriber that signs tokens with secret and
+// links to its handler served at handlerURL. RFC 8058 requires an https URL,
+// and mailbox providers ignore others, so http is only accepted for loopback
+// hosts during development. The secret should be at least 32 random bytes and
+// kept stable, since changing it invalidates links in messages already sent.
+func NewUnsubscriber(client *Client, secret []byte, handlerURL string, opts *UnsubscribeOptions) (*Unsubscriber, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("mailbreeze: unsubscribe secret is required")
+	}
+	u, err := url.Parse(handlerURL)
+	if err != nil || !u.IsAbs() || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("mailbreeze: invalid unsubscribe URL %q", handlerURL)
+	}
+	if u.Scheme == "http" && !isLoopbackHost(u.Hostname()) {
+		return nil, fmt.Errorf("mailbreeze: unsubscribe URL %q must use https", handlerURL)
+	}
+	if opts == nil {
+		opts = &UnsubscribeOptions{}
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultUnsubscribeTTL
+	}
+	return &Unsubscriber{
+		client: client,
+		secret: append([]byte(nil), secret...),
+		url:    u,
+		ttl:    ttl,
+		mailto: opts.Mailto,
+		now:    time.Now,
+	}, nil
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP address.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Token returns a signed token for a contact of a list that expires after the
+// configured TTL. The IDs are escaped so that neither can contain the
+// separator and shift the boundary between them.
+func (u *Unsubscriber) Token(listID, contactID string) string {
+	payload := url.QueryEscape(listID) + "\n" + url.QueryEscape(contactID) + "\n" +
+		strconv.FormatInt(u.now().Add(u.ttl).Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(u.sign(payload))
+}
+
+// Verify checks a token and returns the list and contact it identifies.
+func (u *Unsubscriber) Verify(token string) (listID, contactID string, err error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, u.sign(string(payload))) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	parts := strings.Split(string(payload), "\n")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	listID, listErr := url.QueryUnescape(parts[0])
+	contactID, contactErr := url.QueryUnescape(parts[1])
+	expiry, err := strconv.ParseInt(parts[2], 10, 64)
+	if listErr != nil || contactErr != nil || err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	if !u.now().Before(time.Unix(expiry, 0)) {
+		return "", "", ErrUnsubscribeTokenExpired
+	}
+	return listID, contactID, nil
+}
+
+func (u *Unsubscriber) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// URL returns the one-click unsubscribe URL for a contact of a list.
+func (u *Unsubscriber) URL(listID, contactID string) string {
+	link := *u.url
+	query := link.Query()
+	query.Set("token", u.Token(listID, contactID))
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// Headers returns the List-Unsubscribe and List-Unsubscribe-Post headers for
+// a contact of a list, for use in SendEmailParams.Headers or
+// Personalization.Headers.
+func (u *Unsubscriber) Headers(listID, contactID string) map[string]string {
+	value := "<" + u.URL(listID, contactID) + ">"
+	if u.mailto != "" {
+		value += ", <mailto:" + u.mailto + "?subject=unsubscribe>"
+	}
+	return map[string]string{
+		"List-Unsubscribe":      value,
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+// AddHeaders adds the unsubscribe headers for a contact of a list to params.
+// The Headers map of params is copied, not modified.
+func (u *Unsubscriber) AddHeaders(params *SendEmailParams, listID, contactID string) {
+	params.Headers = mergeMaps(params.Headers, u.Headers(listID, contactID))
+}
+
+// Personalization returns a personalization addressed to a contact of a list
+// with its unsubscribe headers set.
+func (u *Unsubscriber) Personalization(listID string, contact *Contact) Personalization {
+	return Personalization{To: []string{contact.Email}, Headers: u.Headers(listID, contact.ID)}
+}
+
+// Send sends an email to a contact of a list with the unsubscribe headers
+// added. It works on a copy of params; params itself is not modified.
+func (u *Unsubscriber) Send(ctx context.Context, params *SendEmailParams, listID, contactID string, opts ...RequestOption) (*SendEmailResult, error) {
+	if params == nil {
+		return nil, fmt.Errorf("mailbreeze: params are required")
+	}
+	withHeaders := *params
+	u.AddHeaders(&withHeaders, listID, contactID)
+	return u.client.Emails.Send(ctx, &withHeaders, opts...)
+}
+
+// unsubscribeConfirmPage is shown for GET requests, which must not
+// unsubscribe since link scanners and prefetchers follow links.
+var unsubscribeConfirmPage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Unsubscribe</title></head>
+<body style="font-family: -apple-system, 'Segoe UI', Helvetica, Arial, sans-serif; text-align: center; padding: 48px;">
+{{if .Done}}<p>You have been unsubscribed.</p>{{else}}<form method="post">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<p>Unsubscribe from these emails?</p>
+<button type="submit">Unsubscribe</button>
+</form>{{end}}
+</body>
+</html>`))
+
+// ServeHTTP handles unsubscribe requests. A POST, as sent by mailbox
+// providers for one-click unsubscribe or by the confirmation form, suppresses
+// the contact identified by the token query parameter. A GET shows a
+// confirmation form instead. Contacts that are already suppressed or deleted
+// are treated as unsubscribed.
+func (u *Unsubscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	listID, contactID, err := u.Verify(r.URL.Query().Get("token"))
+	if err != nil {
+		http.Error(w, strings.TrimPrefix(err.Error(), "mailbreeze: "), http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		err := u.client.Contacts(listID).Suppress(r.Context(), contactID, SuppressReasonUnsubscribed)
+		if err != nil && !IsNotFoundError(err) && !IsConflictError(err) {
+			http.Error(w, "unsubscribe failed, please try again later", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	// The status is already sent, so a failed write cannot be reported
+	_ = unsubscribeConfirmPage.Execute(w, struct{ Done bool }{r.Method == http.MethodPost})
+}
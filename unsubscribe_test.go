@@ -0,0 +1,235 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestUnsubscriber(t *testing.T, apiURL string) *Unsubscriber {
+	t.Helper()
+	client := NewClient("sk_test_123", WithBaseURL(apiURL), WithMaxRetries(0))
+	unsub, err := NewUnsubscriber(client, []byte("0123456789abcdef0123456789abcdef"), "https://example.com/unsubscribe?src=email", &UnsubscribeOptions{
+		TTL:    time.Hour,
+		Mailto: "unsubscribe@example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return unsub
+}
+
+func TestNewUnsubscriberErrors(t *testing.T) {
+	client := NewClient("sk_test_123")
+	if _, err := NewUnsubscriber(client, nil, "https://example.com/unsubscribe", nil); err == nil {
+		t.Error("expected error for empty secret")
+	}
+	for _, handlerURL := range []string{"/unsubscribe", "ftp://example.com/u", "https://", "http://example.com/unsubscribe"} {
+		if _, err := NewUnsubscriber(client, []byte("secret"), handlerURL, nil); err == nil {
+			t.Errorf("expected error for URL %q", handlerURL)
+		}
+	}
+
+	// Plain http is only allowed for local development
+	for _, handlerURL := range []string{"http://localhost:8080/unsubscribe", "http://127.0.0.1/unsubscribe", "http://[::1]/unsubscribe"} {
+		if _, err := NewUnsubscriber(client, []byte("secret"), handlerURL, nil); err != nil {
+			t.Errorf("unexpected error for URL %q: %v", handlerURL, err)
+		}
+	}
+}
+
+func TestUnsubscribeToken(t *testing.T) {
+	unsub := newTestUnsubscriber(t, "http://127.0.0.1:0")
+	now := time.Now()
+	unsub.now = func() time.Time { return now }
+
+	token := unsub.Token("list_123", "contact_456")
+	listID, contactID, err := unsub.Verify(token)
+	if err != nil || listID != "list_123" || contactID != "contact_456" {
+		t.Fatalf("unexpected Verify result %q, %q, %v", listID, contactID, err)
+	}
+
+	// A token for another contact must not verify with this token's signature
+	other := unsub.Token("list_123", "contact_789")
+	tampered := strings.SplitN(other, ".", 2)[0] + "." + strings.SplitN(token, ".", 2)[1]
+	for _, bad := range []string{"", "abc", tampered, token + "x"} {
+		if _, _, err := unsub.Verify(bad); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+			t.Errorf("Verify(%q): expected ErrInvalidUnsubscribeToken, got %v", bad, err)
+		}
+	}
+
+	// IDs holding the separator cannot shift the boundary between them
+	token = unsub.Token("list\n1", "contact")
+	if listID, contactID, err := unsub.Verify(token); err != nil || listID != "list\n1" || contactID != "contact" {
+		t.Errorf("unexpected Verify result %q, %q, %v", listID, contactID, err)
+	}
+	if unsub.Token("list\n1", "contact") == unsub.Token("list", "1\ncontact") {
+		t.Error("expected tokens for different IDs to differ")
+	}
+
+	now = now.Add(time.Hour)
+	if _, _, err := unsub.Verify(token); !errors.Is(err, ErrUnsubscribeTokenExpired) {
+		t.Errorf("expected ErrUnsubscribeTokenExpired, got %v", err)
+	}
+}
+
+func TestUnsubscribeHeaders(t *testing.T) {
+	unsub := newTestUnsubscriber(t, "http://127.0.0.1:0")
+
+	shared := map[string]string{"X-Campaign": "spring"}
+	params := &SendEmailParams{Headers: shared}
+	unsub.AddHeaders(params, "list_123", "contact_456")
+
+	if params.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" || params.Headers["X-Campaign"] != "spring" {
+		t.Errorf("unexpected headers %v", params.Headers)
+	}
+	if len(shared) != 1 {
+		t.Error("expected the original headers map not to be modified")
+	}
+
+	value := params.Headers["List-Unsubscribe"]
+	link, mailto, ok := strings.Cut(value, ", ")
+	if !ok || mailto != "<mailto:unsubscribe@example.com?subject=unsubscribe>" {
+		t.Fatalf("unexpected List-Unsubscribe %q", value)
+	}
+	u, err := url.Parse(strings.Trim(link, "<>"))
+	if err != nil || u.Host != "example.com" || u.Query().Get("src") != "email" {
+		t.Fatalf("unexpected unsubscribe URL %q", link)
+	}
+	if _, contactID, err := unsub.Verify(u.Query().Get("token")); err != nil || contactID != "contact_456" {
+		t.Errorf("expected URL token to verify, got %q, %v", contactID, err)
+	}
+}
+
+func TestUnsubscribeHandler(t *testing.T) {
+	var suppressed []string
+	var status = http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["reason"] != "unsubscribed" {
+			t.Errorf("expected reason unsubscribed, got %v", body)
+		}
+		suppressed = append(suppressed, r.Method+" "+r.URL.Path)
+
+		w.WriteHeader(status)
+		if status >= 400 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "ERR", "message": "failed"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true})
+	}))
+	defer server.Close()
+
+	unsub := newTestUnsubscriber(t, server.URL)
+	target := "/unsubscribe?token=" + url.QueryEscape(unsub.Token("list_123", "contact_456"))
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		var body *strings.Reader
+		if method == http.MethodPost {
+			body = strings.NewReader("List-Unsubscribe=One-Click")
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(method, target, body).WithContext(context.Background())
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		unsub.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// GET only shows the confirmation form
+	if rec := serve(http.MethodGet, target); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `method="post"`) {
+		t.Errorf("unexpected GET response %d %q", rec.Code, rec.Body.String())
+	}
+	if len(suppressed) != 0 {
+		t.Fatal("expected GET not to suppress")
+	}
+
+	if rec := serve(http.MethodPost, target); rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if len(suppressed) != 1 || suppressed[0] != "POST /api/v1/contact-lists/list_123/contacts/contact_456/suppress" {
+		t.Errorf("unexpected suppress calls %v", suppressed)
+	}
+
+	status = http.StatusNotFound
+	if rec := serve(http.MethodPost, target); rec.Code != http.StatusOK {
+		t.Errorf("expected deleted contact to count as unsubscribed, got %d", rec.Code)
+	}
+	status = http.StatusForbidden
+	if rec := serve(http.MethodPost, target); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when suppress fails, got %d", rec.Code)
+	}
+
+	if rec := serve(http.MethodPost, "/unsubscribe?token=bogus"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid token, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodDelete, target); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestUnsubscribeSend(t *testing.T) {
+	var sent SendEmailParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/emails" {
+			t.Errorf("expected /api/v1/emails, got %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"messageId": "msg_123"},
+		})
+	}))
+	defer server.Close()
+
+	unsub := newTestUnsubscriber(t, server.URL)
+
+	params := &SendEmailParams{From: "news@example.com", To: []string{"jane@example.com"}, Subject: "News"}
+	result, err := unsub.Send(context.Background(), params, "list_123", "contact_456")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MessageID != "msg_123" {
+		t.Errorf("expected messageId 'msg_123', got %q", result.MessageID)
+	}
+	if sent.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" || !strings.Contains(sent.Headers["List-Unsubscribe"], "token=") {
+		t.Errorf("expected unsubscribe headers to be sent, got %v", sent.Headers)
+	}
+	if params.Headers != nil {
+		t.Error("expected params not to be modified")
+	}
+
+	if _, err := unsub.Send(context.Background(), nil, "list_123", "contact_456"); err == nil {
+		t.Error("expected error for nil params")
+	}
+}
+
+func TestUnsubscribePersonalization(t *testing.T) {
+	unsub := newTestUnsubscriber(t, "http://127.0.0.1:0")
+
+	p := unsub.Personalization("list_123", &Contact{ID: "contact_456", Email: "jane@example.com"})
+	if len(p.To) != 1 || p.To[0] != "jane@example.com" {
+		t.Errorf("unexpected To %q", p.To)
+	}
+	link := strings.Trim(strings.SplitN(p.Headers["List-Unsubscribe"], ", ", 2)[0], "<>")
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("unexpected unsubscribe URL %q", link)
+	}
+	if _, contactID, err := unsub.Verify(u.Query().Get("token")); err != nil || contactID != "contact_456" {
+		t.Errorf("expected URL token to verify, got %q, %v", contactID, err)
+	}
+}
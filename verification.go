@@ -2,41 +2,157 @@ package mailbreeze
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
+// ErrVerificationFailed is returned by Wait when a batch verification ends in the failed status.
+var ErrVerificationFailed = errors.New("mailbreeze: batch verification failed")
+
+// ErrVerificationCancelled is returned by Wait when a batch verification ends in the cancelled status.
+var ErrVerificationCancelled = errors.New("mailbreeze: batch verification cancelled")
+
+// Default polling intervals used by Wait.
+const (
+	DefaultWaitPollInterval    = 1 * time.Second
+	DefaultWaitMaxPollInterval = 30 * time.Second
+)
+
+// WaitOptions configures how Wait polls a batch verification.
+type WaitOptions struct {
+	// PollInterval is the delay after the first non-terminal status check;
+	// the first check is made immediately. It doubles after every check up
+	// to MaxPollInterval. Defaults to DefaultWaitPollInterval.
+	PollInterval time.Duration
+
+	// MaxPollInterval caps the delay between status checks.
+	// Defaults to DefaultWaitMaxPollInterval.
+	MaxPollInterval time.Duration
+
+	// OnProgress is called after every status check with the number of
+	// processed and total emails.
+	OnProgress func(processed, total int)
+}
+
 // VerificationResource provides access to email verification operations.
 type VerificationResource struct {
-	client *HTTPClient
+	client   *HTTPClient
+	cache    VerificationCache
+	cacheTTL map[VerificationStatus]time.Duration
 }
 
 // Verify verifies a single email address.
+// If a verification cache is configured, a cached result is returned without calling the API.
 func (r *VerificationResource) Verify(ctx context.Context, params *VerifyEmailParams) (*VerificationResult, error) {
+	if params != nil {
+		if cached, ok := r.cachedResult(ctx, params.Email); ok {
+			return cached, nil
+		}
+	}
+
 	var result VerificationResult
 	if err := r.client.Post(ctx, "/api/v1/email-verification/single", params, &result); err != nil {
 		return nil, err
 	}
+	if result.DidYouMean == "" && result.Result != VerificationStatusValid {
+		result.DidYouMean = SuggestCorrection(result.Email)
+	}
+	r.cacheResult(ctx, &result)
 	return &result, nil
 }
 
+// batchOptions contains options for a batch verification.
+type batchOptions struct {
+	precheck       bool
+	skipDisposable bool
+}
+
+// BatchOption is a function that configures a batch verification.
+type BatchOption func(*batchOptions)
+
+// WithPrecheck runs Precheck on every address and skips the ones it reports
+// as invalid. Skipped addresses are returned in PrecheckFailures.
+func WithPrecheck() BatchOption {
+	return func(o *batchOptions) {
+		o.precheck = true
+	}
+}
+
+// WithSkipDisposable is WithPrecheck that also skips addresses on disposable
+// domains, so no credits are spent on them. They are returned in
+// PrecheckFailures with status risky.
+func WithSkipDisposable() BatchOption {
+	return func(o *batchOptions) {
+		o.precheck = true
+		o.skipDisposable = true
+	}
+}
+
 // Batch starts a batch verification for multiple emails.
-func (r *VerificationResource) Batch(ctx context.Context, emails []string) (*BatchVerificationResult, error) {
+// If a verification cache is configured, addresses with cached results are not
+// sent to the API and are returned in CachedResults instead.
+func (r *VerificationResource) Batch(ctx context.Context, emails []string, opts ...BatchOption) (*BatchVerificationResult, error) {
+	batchOpts := &batchOptions{}
+	for _, opt := range opts {
+		opt(batchOpts)
+	}
+
+	var failures []VerificationResult
+	if batchOpts.precheck {
+		emails, failures = precheckEmails(emails, batchOpts.skipDisposable)
+	}
+
+	emails, cached := r.splitCached(ctx, emails)
+
+	if len(emails) == 0 && (len(failures) > 0 || len(cached) > 0) {
+		// Nothing left to verify, skip the API call
+		return &BatchVerificationResult{
+			Status:           BatchVerificationStatusCompleted,
+			PrecheckFailures: failures,
+			CachedResults:    cached,
+			CreatedAt:        time.Now(),
+		}, nil
+	}
+
 	var result BatchVerificationResult
 	body := map[string][]string{"emails": emails}
 	if err := r.client.Post(ctx, "/api/v1/email-verification/batch", body, &result); err != nil {
 		return nil, err
 	}
+	result.PrecheckFailures = failures
+	result.CachedResults = cached
+	suggestCorrections(&result)
+	r.cacheBatchResults(ctx, &result)
 	return &result, nil
 }
 
+// precheckEmails splits emails into those that pass Precheck and the results
+// of those that are invalid, or disposable if skipDisposable is set.
+func precheckEmails(emails []string, skipDisposable bool) ([]string, []VerificationResult) {
+	passed := make([]string, 0, len(emails))
+	var failures []VerificationResult
+	for _, email := range emails {
+		result := Precheck(email)
+		if result.Result == VerificationStatusInvalid || (skipDisposable && result.Details.IsDisposable) {
+			failures = append(failures, *result)
+			continue
+		}
+		passed = append(passed, email)
+	}
+	return passed, failures
+}
+
 // Get retrieves a batch verification status and results.
 func (r *VerificationResource) Get(ctx context.Context, verificationID string) (*BatchVerificationResult, error) {
 	var result BatchVerificationResult
 	if err := r.client.Get(ctx, fmt.Sprintf("/api/v1/email-verification/%s", verificationID), nil, &result); err != nil {
 		return nil, err
 	}
+	suggestCorrections(&result)
+	r.cacheBatchResults(ctx, &result)
 	return &result, nil
 }
 
@@ -52,7 +168,7 @@ func (r *VerificationResource) List(ctx context.Context, params *ListVerificatio
 			query.Set("limit", strconv.Itoa(params.Limit))
 		}
 		if params.Status != "" {
-			query.Set("status", params.Status)
+			query.Set("status", string(params.Status))
 		}
 	}
 
@@ -63,6 +179,27 @@ func (r *VerificationResource) List(ctx context.Context, params *ListVerificatio
 	return &result, nil
 }
 
+// eachVerification calls fn for every batch verification, fetching one page at a time.
+func (r *VerificationResource) eachVerification(ctx context.Context, fn func(*BatchVerificationResult) error) error {
+	params := ListVerificationsParams{Limit: defaultPageSize}
+	for params.Page = 1; ; params.Page++ {
+		result, err := r.List(ctx, &params)
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Data {
+			if err := fn(&result.Data[i]); err != nil {
+				return err
+			}
+		}
+
+		if !result.Pagination.HasNext || len(result.Data) == 0 {
+			return nil
+		}
+	}
+}
+
 // Stats returns verification statistics.
 func (r *VerificationResource) Stats(ctx context.Context) (*VerificationStats, error) {
 	var stats VerificationStats
@@ -71,3 +208,79 @@ func (r *VerificationResource) Stats(ctx context.Context) (*VerificationStats, e
 	}
 	return &stats, nil
 }
+
+// Wait polls a batch verification until it reaches a terminal status or ctx is done.
+// If the verification ends in any status other than completed, the last result is
+// returned along with ErrVerificationFailed, ErrVerificationCancelled, or an error
+// naming the unrecognized status.
+func (r *VerificationResource) Wait(ctx context.Context, verificationID string, opts *WaitOptions) (*BatchVerificationResult, error) {
+	interval := DefaultWaitPollInterval
+	maxInterval := DefaultWaitMaxPollInterval
+	var onProgress func(processed, total int)
+
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		if opts.MaxPollInterval > 0 {
+			maxInterval = opts.MaxPollInterval
+		}
+		onProgress = opts.OnProgress
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+
+	for {
+		result, err := r.Get(ctx, verificationID)
+		if err != nil {
+			return nil, err
+		}
+
+		if onProgress != nil {
+			onProgress(result.ProcessedEmails, result.TotalEmails)
+		}
+
+		if result.Status.IsTerminal() {
+			return result, batchStatusError(result.Status)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// batchStatusError returns the error for a batch verification that ended in
+// status, or nil if it completed.
+func batchStatusError(status BatchVerificationStatus) error {
+	switch status {
+	case BatchVerificationStatusCompleted:
+		return nil
+	case BatchVerificationStatusFailed:
+		return ErrVerificationFailed
+	case BatchVerificationStatusCancelled:
+		return ErrVerificationCancelled
+	}
+	return fmt.Errorf("mailbreeze: batch verification ended with unexpected status %q", status)
+}
+
+// suggestCorrections fills DidYouMean on detailed batch results the API did
+// not suggest a correction for. Addresses verified as valid are left alone.
+func suggestCorrections(batch *BatchVerificationResult) {
+	detailed := batch.Results.Detailed()
+	for i := range detailed {
+		if detailed[i].DidYouMean == "" && detailed[i].Result != VerificationStatusValid {
+			detailed[i].DidYouMean = SuggestCorrection(detailed[i].Email)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package mailbreeze
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchChunkSize is the default number of emails submitted per batch by BatchLarge.
+const DefaultBatchChunkSize = 1000
+
+// DefaultBatchConcurrency is the default number of batches BatchLarge runs at once.
+const DefaultBatchConcurrency = 4
+
+// BatchLargeOptions configures BatchLarge.
+type BatchLargeOptions struct {
+	// ChunkSize is the maximum number of emails per batch. Defaults to DefaultBatchChunkSize.
+	ChunkSize int
+
+	// Concurrency is the maximum number of batches in flight. Defaults to DefaultBatchConcurrency.
+	Concurrency int
+
+	// Wait configures polling of each batch. Its OnProgress callback is ignored,
+	// use OnProgress below to observe overall progress.
+	Wait *WaitOptions
+
+	// OnProgress is called with the number of processed and total emails across
+	// all batches. It may be called concurrently.
+	OnProgress func(processed, total int)
+
+	// Precheck skips addresses that Precheck reports as invalid.
+	Precheck bool
+
+	// SkipDisposable also skips addresses on disposable domains, as
+	// WithSkipDisposable does. It implies Precheck.
+	SkipDisposable bool
+}
+
+// BatchLargeResult is the merged result of all batches submitted by BatchLarge.
+type BatchLargeResult struct {
+	// VerificationIDs are the IDs of the batches, in submission order.
+	VerificationIDs []string
+
+	// TotalEmails is the number of unique emails verified.
+	TotalEmails int
+
+	// DuplicateEmails is the number of blank or duplicate emails dropped before submission.
+	DuplicateEmails int
+
+	// ProcessedEmails is the number of emails processed across all batches,
+	// not counting results answered from the verification cache.
+	ProcessedEmails int
+
+	// CreditsDeducted is the total number of credits used across all batches.
+	CreditsDeducted int
+
+	// Results are the merged results, including cached results. They are
+	// grouped only if every batch returned grouped results and no result came
+	// from the verification cache, otherwise they are detailed.
+	Results *BatchVerificationResults
+
+	// Analytics is the sum of the analytics of all batches.
+	Analytics BatchVerificationAnalytics
+
+	// PrecheckFailures are the addresses skipped by the Precheck and
+	// SkipDisposable options.
+	PrecheckFailures []VerificationResult
+}
+
+// BatchLarge verifies an arbitrarily large set of emails. Emails are trimmed,
+// lowercased and deduplicated, split into chunks, and submitted with bounded
+// concurrency. It waits for every batch to complete and merges the results.
+// The first error cancels the remaining batches.
+func (r *VerificationResource) BatchLarge(ctx context.Context, emails []string, opts *BatchLargeOptions) (*BatchLargeResult, error) {
+	chunkSize := DefaultBatchChunkSize
+	concurrency := DefaultBatchConcurrency
+	var waitOpts WaitOptions
+	var onProgress func(processed, total int)
+	precheck, skipDisposable := false, false
+
+	if opts != nil {
+		if opts.ChunkSize > 0 {
+			chunkSize = opts.ChunkSize
+		}
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+		if opts.Wait != nil {
+			waitOpts = *opts.Wait
+		}
+		onProgress = opts.OnProgress
+		skipDisposable = opts.SkipDisposable
+		precheck = opts.Precheck || skipDisposable
+	}
+
+	unique := normalizeEmails(emails)
+	duplicates := len(emails) - len(unique)
+
+	var failures []VerificationResult
+	if precheck {
+		unique, failures = precheckEmails(unique, skipDisposable)
+	}
+	chunks := chunkStrings(unique, chunkSize)
+
+	report := &BatchLargeResult{
+		VerificationIDs:  make([]string, len(chunks)),
+		TotalEmails:      len(unique),
+		DuplicateEmails:  duplicates,
+		PrecheckFailures: failures,
+	}
+	if len(chunks) == 0 {
+		report.Results = &BatchVerificationResults{}
+		return report, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*BatchVerificationResult, len(chunks))
+	processed := make([]int, len(chunks))
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	progress := func(index, count int) {
+		if onProgress == nil {
+			return
+		}
+		mu.Lock()
+		processed[index] = count
+		total := 0
+		for _, n := range processed {
+			total += n
+		}
+		mu.Unlock()
+		onProgress(total, len(unique))
+	}
+
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	sem := make(chan struct{}, concurrency)
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(index int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := r.Batch(ctx, chunk)
+			if err != nil {
+				fail(err)
+				return
+			}
+
+			if !result.Status.IsTerminal() {
+				chunkWait := waitOpts
+				chunkWait.OnProgress = func(processed, _ int) {
+					progress(index, processed)
+				}
+				// Get does not know about cache hits, so keep them from the POST result
+				cached := result.CachedResults
+				result, err = r.Wait(ctx, result.VerificationID, &chunkWait)
+				if err != nil {
+					fail(err)
+					return
+				}
+				result.CachedResults = cached
+			} else if err := batchStatusError(result.Status); err != nil {
+				fail(err)
+				return
+			}
+
+			progress(index, result.ProcessedEmails+len(result.CachedResults))
+			results[index] = result
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	mergeBatchResults(report, results)
+	return report, nil
+}
+
+// mergeBatchResults merges the results of completed batches into report.
+func mergeBatchResults(report *BatchLargeResult, results []*BatchVerificationResult) {
+	allGrouped := true
+	for _, result := range results {
+		if result.Results.Grouped() == nil || len(result.CachedResults) > 0 {
+			allGrouped = false
+			break
+		}
+	}
+
+	merged := &BatchVerificationResults{}
+	if allGrouped {
+		merged.grouped = &BatchResults{}
+	}
+
+	for i, result := range results {
+		report.VerificationIDs[i] = result.VerificationID
+		report.ProcessedEmails += result.ProcessedEmails
+		report.CreditsDeducted += result.CreditsDeducted
+
+		analytics := result.Analytics
+		if analytics == nil {
+			analytics = analyticsFromStatuses(result.Results.ByEmail())
+		}
+		addAnalytics(&report.Analytics, analytics)
+
+		if len(result.CachedResults) > 0 {
+			statuses := make(map[string]VerificationStatus, len(result.CachedResults))
+			for _, cached := range result.CachedResults {
+				statuses[cached.Email] = cached.Result
+			}
+			addAnalytics(&report.Analytics, analyticsFromStatuses(statuses))
+			merged.detailed = append(merged.detailed, result.CachedResults...)
+		}
+
+		if allGrouped {
+			grouped := result.Results.Grouped()
+			merged.grouped.Clean = append(merged.grouped.Clean, grouped.Clean...)
+			merged.grouped.Dirty = append(merged.grouped.Dirty, grouped.Dirty...)
+			merged.grouped.Unknown = append(merged.grouped.Unknown, grouped.Unknown...)
+			continue
+		}
+
+		if detailed := result.Results.Detailed(); detailed != nil {
+			merged.detailed = append(merged.detailed, detailed...)
+			continue
+		}
+
+		if grouped := result.Results.Grouped(); grouped != nil {
+			merged.detailed = append(merged.detailed, groupedToDetailed(grouped)...)
+		}
+	}
+
+	report.Results = merged
+}
+
+// addAnalytics adds the counts of src to dst.
+func addAnalytics(dst, src *BatchVerificationAnalytics) {
+	dst.Valid += src.Valid
+	dst.Invalid += src.Invalid
+	dst.Risky += src.Risky
+	dst.Unknown += src.Unknown
+}
+
+// groupedToDetailed converts grouped results into minimal detailed results.
+func groupedToDetailed(grouped *BatchResults) []VerificationResult {
+	results := make([]VerificationResult, 0, len(grouped.Clean)+len(grouped.Dirty)+len(grouped.Unknown))
+	for _, email := range grouped.Clean {
+		results = append(results, VerificationResult{Email: email, IsValid: true, Result: VerificationStatusValid})
+	}
+	for _, email := range grouped.Dirty {
+		results = append(results, VerificationResult{Email: email, Result: VerificationStatusInvalid})
+	}
+	for _, email := range grouped.Unknown {
+		results = append(results, VerificationResult{Email: email, Result: VerificationStatusUnknown})
+	}
+	return results
+}
+
+// analyticsFromStatuses counts verification statuses.
+func analyticsFromStatuses(statuses map[string]VerificationStatus) *BatchVerificationAnalytics {
+	analytics := &BatchVerificationAnalytics{}
+	for _, status := range statuses {
+		switch status {
+		case VerificationStatusValid:
+			analytics.Valid++
+		case VerificationStatusInvalid:
+			analytics.Invalid++
+		case VerificationStatusRisky:
+			analytics.Risky++
+		default:
+			analytics.Unknown++
+		}
+	}
+	return analytics
+}
+
+// normalizeEmails trims and lowercases emails, dropping blanks and duplicates
+// while preserving order.
+func normalizeEmails(emails []string) []string {
+	seen := make(map[string]struct{}, len(emails))
+	unique := make([]string, 0, len(emails))
+	for _, email := range emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			continue
+		}
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		unique = append(unique, email)
+	}
+	return unique
+}
+
+// chunkStrings splits values into slices of at most size elements.
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, values[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,343 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestVerificationBatchLarge(t *testing.T) {
+	var mu sync.Mutex
+	batches := map[string][]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPost {
+			var body map[string][]string
+			json.NewDecoder(r.Body).Decode(&body)
+
+			if len(body["emails"]) > 2 {
+				t.Errorf("expected at most 2 emails per batch, got %d", len(body["emails"]))
+			}
+
+			id := fmt.Sprintf("ver_%d", len(batches)+1)
+			batches[id] = body["emails"]
+
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"verificationId": id,
+					"status":         "processing",
+					"totalEmails":    len(body["emails"]),
+					"createdAt":      "2024-01-01T00:00:00Z",
+				},
+			})
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/email-verification/")
+		emails := batches[id]
+
+		var results []map[string]interface{}
+		for _, email := range emails {
+			status := "valid"
+			if strings.HasPrefix(email, "bad") {
+				status = "invalid"
+			}
+			results = append(results, map[string]interface{}{"email": email, "result": status, "isValid": status == "valid"})
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  id,
+				"status":          "completed",
+				"totalEmails":     len(emails),
+				"processedEmails": len(emails),
+				"creditsDeducted": len(emails),
+				"results":         results,
+				"createdAt":       "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	var lastProcessed int
+	var progressMu sync.Mutex
+	result, err := client.Verification.BatchLarge(context.Background(), []string{
+		"a@example.com",
+		" A@Example.com ",
+		"bad@example.com",
+		"",
+		"c@example.com",
+		"d@example.com",
+		"bad2@example.com",
+	}, &BatchLargeOptions{
+		ChunkSize:   2,
+		Concurrency: 2,
+		Wait:        &WaitOptions{PollInterval: time.Millisecond},
+		OnProgress: func(processed, total int) {
+			progressMu.Lock()
+			defer progressMu.Unlock()
+			if total != 5 {
+				t.Errorf("expected total 5, got %d", total)
+			}
+			if processed > lastProcessed {
+				lastProcessed = processed
+			}
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.VerificationIDs) != 3 {
+		t.Errorf("expected 3 batches, got %d", len(result.VerificationIDs))
+	}
+
+	if result.TotalEmails != 5 {
+		t.Errorf("expected 5 unique emails, got %d", result.TotalEmails)
+	}
+
+	if result.DuplicateEmails != 2 {
+		t.Errorf("expected 2 dropped emails, got %d", result.DuplicateEmails)
+	}
+
+	if result.CreditsDeducted != 5 {
+		t.Errorf("expected 5 credits, got %d", result.CreditsDeducted)
+	}
+
+	if result.ProcessedEmails != 5 {
+		t.Errorf("expected 5 processed, got %d", result.ProcessedEmails)
+	}
+
+	if result.Analytics.Valid != 3 || result.Analytics.Invalid != 2 {
+		t.Errorf("expected 3 valid and 2 invalid, got %+v", result.Analytics)
+	}
+
+	if len(result.Results.Detailed()) != 5 {
+		t.Errorf("expected 5 detailed results, got %d", len(result.Results.Detailed()))
+	}
+
+	if result.Results.ByEmail()["bad2@example.com"] != VerificationStatusInvalid {
+		t.Error("expected bad2@example.com to be invalid")
+	}
+
+	if lastProcessed != 5 {
+		t.Errorf("expected final progress of 5, got %d", lastProcessed)
+	}
+}
+
+func TestVerificationBatchLargeGroupedResults(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  fmt.Sprintf("ver_%d", calls),
+				"status":          "completed",
+				"totalEmails":     1,
+				"processedEmails": 1,
+				"results": map[string]interface{}{
+					"clean": []string{fmt.Sprintf("user%d@example.com", calls)},
+				},
+				"analytics": map[string]interface{}{"valid": 1},
+				"createdAt": "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.BatchLarge(context.Background(), []string{"user1@example.com", "user2@example.com"}, &BatchLargeOptions{
+		ChunkSize:   1,
+		Concurrency: 1,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+
+	grouped := result.Results.Grouped()
+	if grouped == nil || len(grouped.Clean) != 2 {
+		t.Fatalf("expected 2 clean emails, got %+v", grouped)
+	}
+
+	if result.Analytics.Valid != 2 {
+		t.Errorf("expected 2 valid, got %d", result.Analytics.Valid)
+	}
+}
+
+func TestVerificationBatchLargeEmpty(t *testing.T) {
+	client := NewClient("sk_test_123", WithBaseURL("http://127.0.0.1:0"))
+
+	result, err := client.Verification.BatchLarge(context.Background(), []string{" ", ""}, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TotalEmails != 0 || len(result.VerificationIDs) != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestVerificationBatchLargeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   map[string]interface{}{"code": "VALIDATION_ERROR", "message": "Too many emails"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Verification.BatchLarge(context.Background(), []string{"a@example.com", "b@example.com"}, &BatchLargeOptions{ChunkSize: 1})
+
+	if !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestMergeBatchResultsMixedShapes(t *testing.T) {
+	report := &BatchLargeResult{VerificationIDs: make([]string, 2)}
+	mergeBatchResults(report, []*BatchVerificationResult{
+		{
+			VerificationID: "ver_1",
+			Results:        &BatchVerificationResults{detailed: []VerificationResult{{Email: "a@example.com", Result: VerificationStatusRisky}}},
+		},
+		{
+			VerificationID: "ver_2",
+			Results:        &BatchVerificationResults{grouped: &BatchResults{Clean: []string{"b@example.com"}, Dirty: []string{"c@example.com"}, Unknown: []string{"d@example.com"}}},
+		},
+	})
+
+	if report.Results.Grouped() != nil {
+		t.Error("expected mixed results to be merged as detailed")
+	}
+
+	if len(report.Results.Detailed()) != 4 {
+		t.Fatalf("expected 4 detailed results, got %d", len(report.Results.Detailed()))
+	}
+
+	expected := BatchVerificationAnalytics{Valid: 1, Invalid: 1, Risky: 1, Unknown: 1}
+	if report.Analytics != expected {
+		t.Errorf("expected analytics %+v, got %+v", expected, report.Analytics)
+	}
+}
+
+func TestVerificationBatchLargeFailedBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId": "ver_1",
+				"status":         "failed",
+				"createdAt":      "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Verification.BatchLarge(context.Background(), []string{"a@example.com"}, nil)
+
+	if !errors.Is(err, ErrVerificationFailed) {
+		t.Fatalf("expected ErrVerificationFailed, got %v", err)
+	}
+}
+
+func TestVerificationBatchLargeWithPrecheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if len(body["emails"]) != 1 {
+			t.Errorf("expected 1 email, got %v", body["emails"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  "ver_1",
+				"status":          "completed",
+				"totalEmails":     1,
+				"processedEmails": 1,
+				"results":         map[string]interface{}{"clean": body["emails"]},
+				"createdAt":       "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.BatchLarge(context.Background(), []string{"a@example.com", "a@example.com", "broken@"}, &BatchLargeOptions{Precheck: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TotalEmails != 1 {
+		t.Errorf("expected 1 email submitted, got %d", result.TotalEmails)
+	}
+
+	if result.DuplicateEmails != 1 {
+		t.Errorf("expected 1 duplicate, got %d", result.DuplicateEmails)
+	}
+
+	if len(result.PrecheckFailures) != 1 || result.PrecheckFailures[0].Email != "broken@" {
+		t.Errorf("expected broken@ to fail precheck, got %+v", result.PrecheckFailures)
+	}
+}
+
+func TestVerificationBatchLargeWithSkipDisposable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	result, err := client.Verification.BatchLarge(context.Background(), []string{"a@mailinator.com", "broken@"}, &BatchLargeOptions{SkipDisposable: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.VerificationIDs) != 0 {
+		t.Errorf("expected no batches to be submitted, got %v", result.VerificationIDs)
+	}
+
+	if len(result.PrecheckFailures) != 2 {
+		t.Errorf("expected both addresses to be skipped, got %+v", result.PrecheckFailures)
+	}
+}
@@ -0,0 +1,191 @@
+package mailbreeze
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultVerificationCacheSize is the default capacity of a MemoryVerificationCache.
+const DefaultVerificationCacheSize = 10000
+
+// VerificationCache stores verification results so repeated verifications of
+// the same address do not hit the API. Implementations must be safe for
+// concurrent use.
+type VerificationCache interface {
+	// Get returns the cached result for a normalized email, if present and not expired.
+	Get(ctx context.Context, email string) (*VerificationResult, bool)
+
+	// Set stores a result for a normalized email for the given duration.
+	Set(ctx context.Context, email string, result *VerificationResult, ttl time.Duration)
+}
+
+// defaultVerificationCacheTTLs returns how long results are cached by status.
+// Invalid addresses rarely become valid, so they are kept the longest.
+func defaultVerificationCacheTTLs() map[VerificationStatus]time.Duration {
+	return map[VerificationStatus]time.Duration{
+		VerificationStatusValid:   24 * time.Hour,
+		VerificationStatusInvalid: 7 * 24 * time.Hour,
+		VerificationStatusRisky:   24 * time.Hour,
+		VerificationStatusUnknown: time.Hour,
+	}
+}
+
+// MemoryVerificationCache is an in-memory VerificationCache that evicts the
+// least recently used entry when full and expires entries after their TTL.
+type MemoryVerificationCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type memoryCacheEntry struct {
+	email     string
+	result    VerificationResult
+	expiresAt time.Time
+}
+
+// NewMemoryVerificationCache creates an in-memory cache holding at most capacity
+// results. A capacity of zero or less uses DefaultVerificationCacheSize.
+func NewMemoryVerificationCache(capacity int) *MemoryVerificationCache {
+	if capacity <= 0 {
+		capacity = DefaultVerificationCacheSize
+	}
+	return &MemoryVerificationCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get implements VerificationCache.
+func (c *MemoryVerificationCache) Get(_ context.Context, email string) (*VerificationResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[email]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*memoryCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	result := entry.result
+	return &result, true
+}
+
+// Set implements VerificationCache.
+func (c *MemoryVerificationCache) Set(_ context.Context, email string, result *VerificationResult, ttl time.Duration) {
+	if result == nil || ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if elem, ok := c.entries[email]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.result = *result
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[email] = c.order.PushFront(&memoryCacheEntry{
+		email:     email,
+		result:    *result,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// that have not been evicted yet.
+func (c *MemoryVerificationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryVerificationCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*memoryCacheEntry)
+	delete(c.entries, entry.email)
+}
+
+// cacheKey normalizes an email for use as a cache key.
+func cacheKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// cachedResult returns the cached result for email, marked as cached.
+func (r *VerificationResource) cachedResult(ctx context.Context, email string) (*VerificationResult, bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+	result, ok := r.cache.Get(ctx, cacheKey(email))
+	if !ok {
+		return nil, false
+	}
+	result.Cached = true
+	return result, true
+}
+
+// cacheResult stores result using the TTL configured for its status.
+func (r *VerificationResource) cacheResult(ctx context.Context, result *VerificationResult) {
+	if r.cache == nil || result == nil || result.Email == "" {
+		return
+	}
+	ttl := r.cacheTTL[result.Result]
+	if ttl <= 0 {
+		return
+	}
+	r.cache.Set(ctx, cacheKey(result.Email), result, ttl)
+}
+
+// cacheBatchResults stores the detailed results of a completed batch
+// verification. Grouped results are not cached: they carry no details and
+// "dirty" covers risky as well as invalid addresses, so a cache hit could not
+// stand in for a full result.
+func (r *VerificationResource) cacheBatchResults(ctx context.Context, batch *BatchVerificationResult) {
+	if r.cache == nil || batch.Status != BatchVerificationStatusCompleted {
+		return
+	}
+
+	results := batch.Results.Detailed()
+	for i := range results {
+		r.cacheResult(ctx, &results[i])
+	}
+}
+
+// splitCached separates emails that have cached results from those that need
+// to be verified by the API.
+func (r *VerificationResource) splitCached(ctx context.Context, emails []string) ([]string, []VerificationResult) {
+	if r.cache == nil {
+		return emails, nil
+	}
+
+	uncached := make([]string, 0, len(emails))
+	var cached []VerificationResult
+	for _, email := range emails {
+		if result, ok := r.cachedResult(ctx, email); ok {
+			cached = append(cached, *result)
+			continue
+		}
+		uncached = append(uncached, email)
+	}
+	return uncached, cached
+}
@@ -0,0 +1,310 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryVerificationCacheExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryVerificationCache(0)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	cache.Set(ctx, "a@example.com", &VerificationResult{Email: "a@example.com", Result: VerificationStatusValid}, time.Minute)
+
+	if _, ok := cache.Get(ctx, "a@example.com"); !ok {
+		t.Fatal("expected cache hit")
+	}
+
+	now = now.Add(time.Minute)
+
+	if _, ok := cache.Get(ctx, "a@example.com"); ok {
+		t.Fatal("expected entry to be expired")
+	}
+
+	if cache.Len() != 0 {
+		t.Errorf("expected expired entry to be removed, got %d entries", cache.Len())
+	}
+}
+
+func TestMemoryVerificationCacheEviction(t *testing.T) {
+	cache := NewMemoryVerificationCache(2)
+	ctx := context.Background()
+
+	cache.Set(ctx, "a@example.com", &VerificationResult{Email: "a@example.com"}, time.Hour)
+	cache.Set(ctx, "b@example.com", &VerificationResult{Email: "b@example.com"}, time.Hour)
+
+	// Touch a so b becomes the least recently used
+	cache.Get(ctx, "a@example.com")
+	cache.Set(ctx, "c@example.com", &VerificationResult{Email: "c@example.com"}, time.Hour)
+
+	if _, ok := cache.Get(ctx, "b@example.com"); ok {
+		t.Error("expected b@example.com to be evicted")
+	}
+	if _, ok := cache.Get(ctx, "a@example.com"); !ok {
+		t.Error("expected a@example.com to be cached")
+	}
+	if _, ok := cache.Get(ctx, "c@example.com"); !ok {
+		t.Error("expected c@example.com to be cached")
+	}
+
+	// Updating an existing entry does not grow the cache
+	cache.Set(ctx, "c@example.com", &VerificationResult{Email: "c@example.com", Result: VerificationStatusRisky}, time.Hour)
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	result, _ := cache.Get(ctx, "c@example.com")
+	if result.Result != VerificationStatusRisky {
+		t.Errorf("expected updated result, got '%s'", result.Result)
+	}
+
+	// Zero TTL and nil results are ignored
+	cache.Set(ctx, "d@example.com", &VerificationResult{Email: "d@example.com"}, 0)
+	cache.Set(ctx, "e@example.com", nil, time.Hour)
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestVerificationVerifyCached(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"email":   "test@example.com",
+				"result":  "valid",
+				"isValid": true,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithVerificationCache(NewMemoryVerificationCache(10)))
+
+	for i := 0; i < 3; i++ {
+		result, err := client.Verification.Verify(context.Background(), &VerifyEmailParams{Email: "Test@Example.com"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Result != VerificationStatusValid {
+			t.Errorf("expected result 'valid', got '%s'", result.Result)
+		}
+		if i > 0 && !result.Cached {
+			t.Error("expected cached result")
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 API call, got %d", calls)
+	}
+}
+
+func TestVerificationVerifyCacheTTLPerStatus(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"email":  "test@example.com",
+				"result": "unknown",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123",
+		WithBaseURL(server.URL),
+		WithVerificationCache(NewMemoryVerificationCache(10)),
+		WithVerificationCacheTTL(VerificationStatusUnknown, 0),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Verification.Verify(context.Background(), &VerifyEmailParams{Email: "test@example.com"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected unknown results not to be cached, got %d API calls", calls)
+	}
+}
+
+func TestVerificationBatchCached(t *testing.T) {
+	var sent [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		json.NewDecoder(r.Body).Decode(&body)
+		sent = append(sent, body["emails"])
+
+		var results []map[string]interface{}
+		for _, email := range body["emails"] {
+			results = append(results, map[string]interface{}{"email": email, "result": "invalid"})
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  "ver_123",
+				"status":          "completed",
+				"totalEmails":     len(body["emails"]),
+				"processedEmails": len(body["emails"]),
+				"results":         results,
+				"createdAt":       "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithVerificationCache(NewMemoryVerificationCache(10)))
+
+	if _, err := client.Verification.Batch(context.Background(), []string{"a@example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := client.Verification.Batch(context.Background(), []string{"a@example.com", "b@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 2 || len(sent[1]) != 1 || sent[1][0] != "b@example.com" {
+		t.Fatalf("expected only b@example.com to be sent, got %v", sent)
+	}
+
+	if len(result.CachedResults) != 1 || !result.CachedResults[0].Cached {
+		t.Fatalf("expected 1 cached result, got %+v", result.CachedResults)
+	}
+
+	result, err = client.Verification.Batch(context.Background(), []string{"a@example.com", "b@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Errorf("expected fully cached batch to skip the API, got %d calls", len(sent))
+	}
+
+	if result.Status != BatchVerificationStatusCompleted || len(result.CachedResults) != 2 {
+		t.Errorf("expected completed result with 2 cached results, got %+v", result)
+	}
+}
+
+func TestVerificationGetDoesNotCacheGroupedResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId": "ver_123",
+				"status":         "completed",
+				"results":        map[string]interface{}{"dirty": []string{"bad@example.com"}},
+				"createdAt":      "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	cache := NewMemoryVerificationCache(10)
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithVerificationCache(cache))
+
+	if _, err := client.Verification.Get(context.Background(), "ver_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result, ok := cache.Get(context.Background(), "bad@example.com"); ok {
+		t.Errorf("expected grouped result not to be cached, got %+v", result)
+	}
+}
+
+func TestVerificationBatchLargeMergesCachedResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  "ver_1",
+				"status":          "completed",
+				"processedEmails": len(body["emails"]),
+				"results":         map[string]interface{}{"clean": body["emails"]},
+				"createdAt":       "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	cache := NewMemoryVerificationCache(10)
+	cache.Set(context.Background(), "a@example.com", &VerificationResult{Email: "a@example.com", Result: VerificationStatusRisky}, time.Hour)
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithVerificationCache(cache))
+
+	result, err := client.Verification.BatchLarge(context.Background(), []string{"a@example.com", "b@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Results.Grouped() != nil {
+		t.Error("expected results with cache hits to be detailed")
+	}
+
+	byEmail := result.Results.ByEmail()
+	if byEmail["a@example.com"] != VerificationStatusRisky || byEmail["b@example.com"] != VerificationStatusValid {
+		t.Errorf("unexpected merged results: %v", byEmail)
+	}
+
+	if result.Analytics.Risky != 1 || result.Analytics.Valid != 1 {
+		t.Errorf("expected 1 risky and 1 valid, got %+v", result.Analytics)
+	}
+}
+
+func TestVerificationBatchLargeMergesCachedResultsAfterWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := map[string]interface{}{
+			"verificationId": "ver_1",
+			"status":         "processing",
+			"createdAt":      "2024-01-01T00:00:00Z",
+		}
+		if r.Method == http.MethodGet {
+			data["status"] = "completed"
+			data["processedEmails"] = 1
+			data["results"] = map[string]interface{}{"clean": []string{"b@example.com"}}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data})
+	}))
+	defer server.Close()
+
+	cache := NewMemoryVerificationCache(10)
+	cache.Set(context.Background(), "a@example.com", &VerificationResult{Email: "a@example.com", Result: VerificationStatusInvalid}, time.Hour)
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithVerificationCache(cache))
+
+	result, err := client.Verification.BatchLarge(context.Background(), []string{"a@example.com", "b@example.com"}, &BatchLargeOptions{
+		Wait: &WaitOptions{PollInterval: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byEmail := result.Results.ByEmail()
+	if byEmail["a@example.com"] != VerificationStatusInvalid || byEmail["b@example.com"] != VerificationStatusValid {
+		t.Errorf("expected cache hit to survive polling, got %v", byEmail)
+	}
+	if result.Analytics.Invalid != 1 || result.Analytics.Valid != 1 {
+		t.Errorf("expected 1 invalid and 1 valid, got %+v", result.Analytics)
+	}
+}
@@ -0,0 +1,198 @@
+package mailbreeze
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultCleanListPageSize is the default number of contacts fetched per page by CleanList.
+const DefaultCleanListPageSize = 100
+
+// CleanListActionType is the action CleanList takes on a contact.
+type CleanListActionType string
+
+const (
+	CleanListActionSuppress CleanListActionType = "suppress"
+	CleanListActionTag      CleanListActionType = "tag"
+)
+
+// CleanListPolicy configures which contacts CleanList acts on.
+type CleanListPolicy struct {
+	// SuppressInvalid suppresses invalid contacts with SuppressReasonBounced.
+	SuppressInvalid bool
+
+	// SuppressDirty makes SuppressInvalid also suppress addresses reported
+	// as "dirty" when a batch returns grouped results. Dirty addresses can be
+	// risky rather than invalid, so they are left alone by default.
+	SuppressDirty bool
+
+	// SuppressSpamTraps suppresses known spam traps with SuppressReasonSpamTrap.
+	SuppressSpamTraps bool
+
+	// TagRiskyField is the custom field set to "risky" on risky contacts.
+	// Risky contacts are not tagged when empty.
+	TagRiskyField string
+
+	// DryRun reports the actions that would be taken without applying them.
+	DryRun bool
+
+	// PageSize is the number of contacts fetched per page. Defaults to DefaultCleanListPageSize.
+	PageSize int
+
+	// Batch configures the batch verification of the list's emails.
+	Batch *BatchLargeOptions
+}
+
+// CleanListAction is an action taken (or planned, in dry-run mode) on a contact.
+type CleanListAction struct {
+	ContactID string
+	Email     string
+	Status    VerificationStatus
+	Action    CleanListActionType
+
+	// Reason is the suppression reason for suppress actions.
+	Reason SuppressReason
+
+	// Err is the error returned when applying the action, if any.
+	Err error
+}
+
+// CleanListReport summarizes a CleanList run.
+type CleanListReport struct {
+	ListID          string
+	DryRun          bool
+	TotalContacts   int
+	VerifiedEmails  int
+	CreditsDeducted int
+	Analytics       BatchVerificationAnalytics
+	Actions         []CleanListAction
+
+	// Failed is the number of actions that returned an error.
+	Failed int
+}
+
+// CleanList verifies every active contact in a list and applies policy to the
+// results. Errors applying individual actions are recorded in the report and
+// do not stop the run.
+func (r *VerificationResource) CleanList(ctx context.Context, listID string, policy *CleanListPolicy) (*CleanListReport, error) {
+	if policy == nil {
+		policy = &CleanListPolicy{}
+	}
+	pageSize := policy.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultCleanListPageSize
+	}
+
+	contacts := &ContactsResource{client: r.client, listID: listID}
+
+	// Collect contacts by normalized email
+	byEmail := make(map[string][]Contact)
+	var emails []string
+	total := 0
+	filter := &ListContactsParams{Status: ContactStatusActive, Limit: pageSize}
+	err := contacts.eachContact(ctx, filter, func(contact *Contact) error {
+		key := strings.ToLower(strings.TrimSpace(contact.Email))
+		if _, ok := byEmail[key]; !ok {
+			emails = append(emails, key)
+		}
+		byEmail[key] = append(byEmail[key], *contact)
+		total++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CleanListReport{
+		ListID:        listID,
+		DryRun:        policy.DryRun,
+		TotalContacts: total,
+	}
+	if len(emails) == 0 {
+		return report, nil
+	}
+
+	batch, err := r.BatchLarge(ctx, emails, policy.Batch)
+	if err != nil {
+		return nil, err
+	}
+	report.VerifiedEmails = batch.TotalEmails
+	report.CreditsDeducted = batch.CreditsDeducted
+	report.Analytics = batch.Analytics
+
+	results := make(map[string]VerificationResult)
+	for _, result := range batch.Results.Detailed() {
+		results[strings.ToLower(result.Email)] = result
+	}
+	if grouped := batch.Results.Grouped(); grouped != nil {
+		for _, result := range groupedToDetailed(grouped) {
+			email := strings.ToLower(result.Email)
+			if result.Result == VerificationStatusInvalid && !policy.SuppressDirty {
+				// Dirty covers risky as well as invalid addresses, which
+				// must not be suppressed as bounces
+				continue
+			}
+			results[email] = result
+		}
+	}
+	for _, result := range batch.PrecheckFailures {
+		results[strings.ToLower(result.Email)] = result
+	}
+
+	for _, email := range emails {
+		result, ok := results[email]
+		if !ok {
+			continue
+		}
+		for _, contact := range byEmail[email] {
+			action, ok := cleanListAction(policy, &contact, &result)
+			if !ok {
+				continue
+			}
+			if !policy.DryRun {
+				action.Err = applyCleanListAction(ctx, contacts, policy, &contact, &action)
+				if action.Err != nil {
+					report.Failed++
+				}
+			}
+			report.Actions = append(report.Actions, action)
+		}
+	}
+
+	return report, nil
+}
+
+// cleanListAction decides the action policy requires for a contact, if any.
+func cleanListAction(policy *CleanListPolicy, contact *Contact, result *VerificationResult) (CleanListAction, bool) {
+	action := CleanListAction{
+		ContactID: contact.ID,
+		Email:     contact.Email,
+		Status:    result.Result,
+	}
+
+	switch {
+	case policy.SuppressSpamTraps && result.Details != nil && result.Details.IsSpamTrap:
+		action.Action = CleanListActionSuppress
+		action.Reason = SuppressReasonSpamTrap
+	case policy.SuppressInvalid && result.Result == VerificationStatusInvalid:
+		action.Action = CleanListActionSuppress
+		action.Reason = SuppressReasonBounced
+	case policy.TagRiskyField != "" && result.Result == VerificationStatusRisky:
+		action.Action = CleanListActionTag
+	default:
+		return action, false
+	}
+	return action, true
+}
+
+// applyCleanListAction applies a planned action to a contact.
+func applyCleanListAction(ctx context.Context, contacts *ContactsResource, policy *CleanListPolicy, contact *Contact, action *CleanListAction) error {
+	if action.Action == CleanListActionSuppress {
+		return contacts.Suppress(ctx, contact.ID, action.Reason)
+	}
+
+	// Send only the tag so fields changed since the contact was listed are kept
+	fields := map[string]interface{}{policy.TagRiskyField: string(VerificationStatusRisky)}
+	_, err := contacts.Update(ctx, contact.ID, &UpdateContactParams{CustomFields: fields})
+	return err
+}
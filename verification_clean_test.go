@@ -0,0 +1,317 @@
+package mailbreeze
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newCleanListServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	var mutations []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/contact-lists/list_123/contacts":
+			if r.URL.Query().Get("status") != "active" {
+				t.Errorf("expected status active, got %s", r.URL.Query().Get("status"))
+			}
+
+			contacts := []map[string]interface{}{
+				{"id": "c1", "email": "good@example.com", "status": "active", "createdAt": "2024-01-01T00:00:00Z"},
+				{"id": "c2", "email": "bad@example.com", "status": "active", "createdAt": "2024-01-01T00:00:00Z"},
+			}
+			hasNext := true
+			if r.URL.Query().Get("page") == "2" {
+				contacts = []map[string]interface{}{
+					{"id": "c3", "email": "Trap@Example.com", "status": "active", "createdAt": "2024-01-01T00:00:00Z"},
+					{"id": "c4", "email": "maybe@example.com", "status": "active", "customFields": map[string]interface{}{"plan": "pro"}, "createdAt": "2024-01-01T00:00:00Z"},
+				}
+				hasNext = false
+			}
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"data":       contacts,
+					"pagination": map[string]interface{}{"page": 1, "limit": 2, "total": 4, "totalPages": 2, "hasNext": hasNext},
+				},
+			})
+
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/email-verification/batch":
+			var body map[string][]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body["emails"]) != 4 {
+				t.Errorf("expected 4 emails, got %v", body["emails"])
+			}
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"verificationId":  "ver_123",
+					"status":          "completed",
+					"totalEmails":     4,
+					"processedEmails": 4,
+					"creditsDeducted": 4,
+					"results": []map[string]interface{}{
+						{"email": "good@example.com", "result": "valid", "isValid": true},
+						{"email": "bad@example.com", "result": "invalid"},
+						{"email": "trap@example.com", "result": "invalid", "details": map[string]interface{}{"isSpamTrap": true}},
+						{"email": "maybe@example.com", "result": "risky"},
+					},
+					"analytics": map[string]interface{}{"valid": 1, "invalid": 2, "risky": 1},
+					"createdAt": "2024-01-01T00:00:00Z",
+				},
+			})
+
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/suppress"):
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+
+			mu.Lock()
+			mutations = append(mutations, r.URL.Path+" "+body["reason"])
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true})
+
+		case r.Method == http.MethodPatch:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			fields, _ := body["customFields"].(map[string]interface{})
+			if len(fields) != 1 || fields["verification"] != "risky" {
+				t.Errorf("expected only the tag field to be sent, got %v", fields)
+			}
+
+			mu.Lock()
+			mutations = append(mutations, r.URL.Path+" tag")
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"id": "c4", "email": "maybe@example.com"},
+			})
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, &mutations
+}
+
+func TestVerificationCleanList(t *testing.T) {
+	server, mutations := newCleanListServer(t)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	report, err := client.Verification.CleanList(context.Background(), "list_123", &CleanListPolicy{
+		SuppressInvalid:   true,
+		SuppressSpamTraps: true,
+		TagRiskyField:     "verification",
+		PageSize:          2,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.TotalContacts != 4 || report.VerifiedEmails != 4 {
+		t.Errorf("expected 4 contacts verified, got %d/%d", report.TotalContacts, report.VerifiedEmails)
+	}
+
+	if report.CreditsDeducted != 4 {
+		t.Errorf("expected 4 credits, got %d", report.CreditsDeducted)
+	}
+
+	if len(report.Actions) != 3 {
+		t.Fatalf("expected 3 actions, got %+v", report.Actions)
+	}
+
+	expected := []string{
+		"/api/v1/contact-lists/list_123/contacts/c2/suppress bounced",
+		"/api/v1/contact-lists/list_123/contacts/c3/suppress spam_trap",
+		"/api/v1/contact-lists/list_123/contacts/c4 tag",
+	}
+	if strings.Join(*mutations, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected mutations %v, got %v", expected, *mutations)
+	}
+
+	if report.Failed != 0 {
+		t.Errorf("expected no failures, got %d", report.Failed)
+	}
+}
+
+func TestVerificationCleanListDryRun(t *testing.T) {
+	server, mutations := newCleanListServer(t)
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	report, err := client.Verification.CleanList(context.Background(), "list_123", &CleanListPolicy{
+		SuppressInvalid: true,
+		DryRun:          true,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*mutations) != 0 {
+		t.Errorf("expected no mutations in dry run, got %v", *mutations)
+	}
+
+	// Without SuppressSpamTraps the trap is still invalid and suppressed as bounced
+	if len(report.Actions) != 2 {
+		t.Fatalf("expected 2 planned actions, got %+v", report.Actions)
+	}
+
+	for _, action := range report.Actions {
+		if action.Action != CleanListActionSuppress || action.Reason != SuppressReasonBounced {
+			t.Errorf("unexpected action %+v", action)
+		}
+	}
+
+	if !report.DryRun {
+		t.Error("expected report to be marked as dry run")
+	}
+}
+
+func TestVerificationCleanListActionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"data": []map[string]interface{}{{"id": "c1", "email": "bad@example.com", "createdAt": "2024-01-01T00:00:00Z"}},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/batch"):
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"verificationId": "ver_123",
+					"status":         "completed",
+					"results":        map[string]interface{}{"dirty": []string{"bad@example.com"}},
+					"createdAt":      "2024-01-01T00:00:00Z",
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   map[string]interface{}{"code": "VALIDATION_ERROR", "message": "Already suppressed"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	report, err := client.Verification.CleanList(context.Background(), "list_123", &CleanListPolicy{SuppressInvalid: true, SuppressDirty: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Failed != 1 || len(report.Actions) != 1 || !IsValidationError(report.Actions[0].Err) {
+		t.Errorf("expected 1 failed action, got %+v", report)
+	}
+}
+
+func TestVerificationCleanListKeepsDirtyByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"data": []map[string]interface{}{{"id": "c1", "email": "dirty@example.com", "createdAt": "2024-01-01T00:00:00Z"}},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/batch"):
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"verificationId": "ver_123",
+					"status":         "completed",
+					"results":        map[string]interface{}{"dirty": []string{"dirty@example.com"}},
+					"createdAt":      "2024-01-01T00:00:00Z",
+				},
+			})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	report, err := client.Verification.CleanList(context.Background(), "list_123", &CleanListPolicy{SuppressInvalid: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Actions) != 0 {
+		t.Errorf("expected dirty addresses not to be suppressed, got %+v", report.Actions)
+	}
+}
+
+func TestVerificationCleanListEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"data": []map[string]interface{}{}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	report, err := client.Verification.CleanList(context.Background(), "list_123", nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.TotalContacts != 0 || len(report.Actions) != 0 {
+		t.Errorf("expected empty report, got %+v", report)
+	}
+}
+
+func TestVerificationCleanListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "List not found"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Verification.CleanList(context.Background(), "list_123", nil)
+
+	if !IsNotFoundError(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
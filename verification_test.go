@@ -3,9 +3,12 @@ package mailbreeze
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestVerificationVerify(t *testing.T) {
@@ -233,3 +236,415 @@ func TestVerificationStats(t *testing.T) {
 		t.Errorf("expected totalValid 850, got %d", stats.TotalValid)
 	}
 }
+
+func TestVerificationGetDetailedResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  "ver_123",
+				"status":          "completed",
+				"totalEmails":     2,
+				"processedEmails": 2,
+				"results": []map[string]interface{}{
+					{"email": "a@example.com", "result": "valid", "isValid": true},
+					{"email": "b@example.com", "result": "risky", "isValid": false},
+				},
+				"createdAt": "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.Get(context.Background(), "ver_123")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Results.Detailed()) != 2 {
+		t.Fatalf("expected 2 detailed results, got %d", len(result.Results.Detailed()))
+	}
+
+	if result.Results.Grouped() != nil {
+		t.Error("expected grouped results to be nil")
+	}
+
+	byEmail := result.Results.ByEmail()
+	if byEmail["a@example.com"] != VerificationStatusValid {
+		t.Errorf("expected a@example.com to be valid, got '%s'", byEmail["a@example.com"])
+	}
+	if byEmail["b@example.com"] != VerificationStatusRisky {
+		t.Errorf("expected b@example.com to be risky, got '%s'", byEmail["b@example.com"])
+	}
+}
+
+func TestVerificationBatchGroupedResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  "ver_123",
+				"status":          "completed",
+				"totalEmails":     3,
+				"processedEmails": 3,
+				"results": map[string]interface{}{
+					"clean":   []string{"a@example.com"},
+					"dirty":   []string{"b@example.com"},
+					"unknown": []string{"c@example.com"},
+				},
+				"createdAt": "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.Batch(context.Background(), []string{"a@example.com", "b@example.com", "c@example.com"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Results.Detailed() != nil {
+		t.Error("expected detailed results to be nil")
+	}
+
+	grouped := result.Results.Grouped()
+	if grouped == nil || len(grouped.Clean) != 1 {
+		t.Fatalf("expected 1 clean email, got %+v", grouped)
+	}
+
+	byEmail := result.Results.ByEmail()
+	expected := map[string]VerificationStatus{
+		"a@example.com": VerificationStatusValid,
+		"b@example.com": VerificationStatusInvalid,
+		"c@example.com": VerificationStatusUnknown,
+	}
+	for email, status := range expected {
+		if byEmail[email] != status {
+			t.Errorf("expected %s to be '%s', got '%s'", email, status, byEmail[email])
+		}
+	}
+}
+
+func TestBatchVerificationResultsEmpty(t *testing.T) {
+	var result BatchVerificationResult
+	if err := json.Unmarshal([]byte(`{"verificationId":"ver_123","results":null}`), &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Results.Detailed() != nil || result.Results.Grouped() != nil {
+		t.Error("expected no results")
+	}
+
+	if len(result.Results.ByEmail()) != 0 {
+		t.Error("expected empty ByEmail map")
+	}
+}
+
+func TestBatchVerificationResultsInvalidJSON(t *testing.T) {
+	var results BatchVerificationResults
+	if err := json.Unmarshal([]byte(`"not results"`), &results); err == nil {
+		t.Fatal("expected error for invalid results")
+	}
+}
+
+func TestBatchVerificationResultsMarshalRoundTrip(t *testing.T) {
+	inputs := []string{
+		`[{"email":"a@example.com","isValid":true,"result":"valid"}]`,
+		`{"clean":["a@example.com"],"dirty":["b@example.com"]}`,
+		`null`,
+	}
+
+	for _, input := range inputs {
+		var results BatchVerificationResults
+		if err := json.Unmarshal([]byte(input), &results); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		output, err := json.Marshal(results)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(output) != input {
+			t.Errorf("expected %s, got %s", input, string(output))
+		}
+	}
+}
+
+func TestVerificationWait(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/email-verification/ver_123" {
+			t.Errorf("expected /api/v1/email-verification/ver_123, got %s", r.URL.Path)
+		}
+		calls++
+
+		status := "processing"
+		if calls >= 3 {
+			status = "completed"
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId":  "ver_123",
+				"status":          status,
+				"totalEmails":     3,
+				"processedEmails": calls,
+				"createdAt":       "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	var progress []int
+	result, err := client.Verification.Wait(context.Background(), "ver_123", &WaitOptions{
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: 2 * time.Millisecond,
+		OnProgress: func(processed, total int) {
+			if total != 3 {
+				t.Errorf("expected total 3, got %d", total)
+			}
+			progress = append(progress, processed)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != BatchVerificationStatusCompleted {
+		t.Errorf("expected status 'completed', got '%s'", result.Status)
+	}
+
+	if len(progress) != 3 || progress[2] != 3 {
+		t.Errorf("expected progress [1 2 3], got %v", progress)
+	}
+}
+
+func TestVerificationWaitFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId": "ver_123",
+				"status":         "failed",
+				"createdAt":      "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.Wait(context.Background(), "ver_123", nil)
+
+	if !errors.Is(err, ErrVerificationFailed) {
+		t.Fatalf("expected ErrVerificationFailed, got %v", err)
+	}
+
+	if result == nil || result.Status != BatchVerificationStatusFailed {
+		t.Errorf("expected failed result, got %+v", result)
+	}
+}
+
+func TestVerificationWaitStopsOnOtherTerminalStatuses(t *testing.T) {
+	tests := map[string]func(error) bool{
+		"cancelled": func(err error) bool { return errors.Is(err, ErrVerificationCancelled) },
+		"archived":  func(err error) bool { return err != nil && strings.Contains(err.Error(), `"archived"`) },
+	}
+
+	for status, check := range tests {
+		t.Run(status, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"success": true,
+					"data": map[string]interface{}{
+						"verificationId": "ver_123",
+						"status":         status,
+						"createdAt":      "2024-01-01T00:00:00Z",
+					},
+				})
+			}))
+			defer server.Close()
+
+			client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			result, err := client.Verification.Wait(ctx, "ver_123", nil)
+
+			if !check(err) {
+				t.Fatalf("unexpected error for status %s: %v", status, err)
+			}
+			if result == nil || string(result.Status) != status {
+				t.Errorf("expected %s result, got %+v", status, result)
+			}
+			if requests != 1 {
+				t.Errorf("expected a single poll, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestVerificationWaitContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId": "ver_123",
+				"status":         "pending",
+				"createdAt":      "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Verification.Wait(ctx, "ver_123", &WaitOptions{PollInterval: time.Hour, MaxPollInterval: 5 * time.Millisecond})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestVerificationWaitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   map[string]interface{}{"code": "NOT_FOUND", "message": "Not found"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	_, err := client.Verification.Wait(context.Background(), "ver_123", nil)
+
+	if !IsNotFoundError(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestBatchVerificationStatusIsTerminal(t *testing.T) {
+	tests := map[BatchVerificationStatus]bool{
+		BatchVerificationStatusPending:    false,
+		BatchVerificationStatusProcessing: false,
+		BatchVerificationStatusCompleted:  true,
+		BatchVerificationStatusFailed:     true,
+		BatchVerificationStatusCancelled:  true,
+		"archived":                        true,
+	}
+
+	for status, expected := range tests {
+		if status.IsTerminal() != expected {
+			t.Errorf("expected %s.IsTerminal() to be %v", status, expected)
+		}
+	}
+}
+
+func TestVerificationBatchWithPrecheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		// Disposable addresses are risky, not invalid, so they are still verified
+		if len(body["emails"]) != 2 || body["emails"][0] != "a@example.com" || body["emails"][1] != "b@mailinator.com" {
+			t.Errorf("expected a@example.com and b@mailinator.com to be sent, got %v", body["emails"])
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"verificationId": "ver_123",
+				"status":         "processing",
+				"totalEmails":    2,
+				"createdAt":      "2024-01-01T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL))
+
+	result, err := client.Verification.Batch(context.Background(), []string{"a@example.com", "not-an-email", "b@mailinator.com"}, WithPrecheck())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.PrecheckFailures) != 1 {
+		t.Fatalf("expected 1 precheck failure, got %d", len(result.PrecheckFailures))
+	}
+
+	if result.PrecheckFailures[0].Reason != PrecheckReasonInvalidSyntax {
+		t.Errorf("expected invalid syntax, got '%s'", result.PrecheckFailures[0].Reason)
+	}
+}
+
+func TestVerificationBatchWithSkipDisposable(t *testing.T) {
+	// No API call may be made, so any request fails the test
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_test_123", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	result, err := client.Verification.Batch(context.Background(), []string{"not-an-email", "b@mailinator.com"}, WithSkipDisposable())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.PrecheckFailures) != 2 {
+		t.Fatalf("expected 2 precheck failures, got %d", len(result.PrecheckFailures))
+	}
+
+	disposable := result.PrecheckFailures[1]
+	if disposable.Email != "b@mailinator.com" || disposable.Result != VerificationStatusRisky || disposable.Reason != PrecheckReasonDisposable {
+		t.Errorf("expected b@mailinator.com to be skipped as risky disposable, got %+v", disposable)
+	}
+}
+
+func TestVerificationBatchWithPrecheckAllInvalid(t *testing.T) {
+	client := NewClient("sk_test_123", WithBaseURL("http://127.0.0.1:0"))
+
+	result, err := client.Verification.Batch(context.Background(), []string{"not-an-email"}, WithPrecheck())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != BatchVerificationStatusCompleted {
+		t.Errorf("expected status 'completed', got '%s'", result.Status)
+	}
+
+	if len(result.PrecheckFailures) != 1 {
+		t.Errorf("expected 1 precheck failure, got %d", len(result.PrecheckFailures))
+	}
+}